@@ -0,0 +1,206 @@
+// Package analysis reviews a finished game, comparing every decision with
+// the choice that maximises the expected score of the turn
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+type Kind string
+
+const (
+	Accept Kind = "accept"
+	Keep   Kind = "keep"
+	Bank   Kind = "bank"
+)
+
+// Decision is a choice a player made and how much expected score it lost
+// against the best choice
+type Decision struct {
+	// Event is the index of the event that settled the decision
+	Event  int
+	Turn   int
+	Player string
+	Kind   Kind
+	Chosen string
+	Best   string
+	Loss   float64
+}
+
+// Report is the review of a game
+type Report struct {
+	Decisions []Decision
+	players   []string
+	names     map[string]string
+}
+
+// Loss returns the expected score the player lost over the game
+func (r *Report) Loss(player string) float64 {
+	var sum float64
+	for _, d := range r.Decisions {
+		if d.Player == player {
+			sum += d.Loss
+		}
+	}
+	return sum
+}
+
+// Blunders returns the player's n most costly decisions
+func (r *Report) Blunders(player string, n int) []Decision {
+	ret := make([]Decision, 0)
+	for _, d := range r.Decisions {
+		if d.Player == player && d.Loss > 0 {
+			ret = append(ret, d)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Loss > ret[j].Loss
+	})
+	return ret[:min(n, len(ret))]
+}
+
+// Write writes the review as text, listing each player's worst n decisions
+func (r *Report) Write(w io.Writer, n int) error {
+	for _, player := range r.players {
+		var decisions int
+		for _, d := range r.Decisions {
+			if d.Player == player {
+				decisions++
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s: %d decisions, %.0f expected points lost\n", r.names[player], decisions, r.Loss(player)); err != nil {
+			return err
+		}
+		for _, d := range r.Blunders(player, n) {
+			if _, err := fmt.Fprintf(w, "  turn %d: %s, better to %s (-%.0f)\n", d.Turn, d.Chosen, d.Best, d.Loss); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// analyzer follows a replay of the game, noting each decision as the
+// event that settles it is applied
+type analyzer struct {
+	solver *strategy.Solver
+	report *Report
+	turn   int
+	bank   int
+	// score is the turn score before any dice were kept from roll, the
+	// latest roll in the game
+	score uint32
+	roll  game.Roll
+}
+
+// Analyze replays the game from its events and reviews every decision.
+// Keeps are judged together once the player rolls again or banks.
+func Analyze(events []game.Event, solver *strategy.Solver) (*Report, error) {
+	a := &analyzer{solver: solver, report: &Report{names: make(map[string]string)}}
+	g := game.NewGame()
+	for i, event := range events {
+		a.before(g, i, event)
+		if err := g.ApplyEvent(event); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", i, event.Type, err)
+		}
+		a.after(g, event)
+	}
+	return a.report, nil
+}
+
+func (a *analyzer) before(g *game.Game, i int, event game.Event) {
+	switch event.Type {
+	case game.EventJoined:
+		a.report.players = append(a.report.players, event.Player)
+		a.report.names[event.Player] = event.Name
+	case game.EventAccepted, game.EventRejected:
+		a.turn++
+		dice, score := g.Offer()
+		if dice == 0 {
+			return
+		}
+		accept, reject := a.solver.Roll(score, dice), a.solver.Roll(0, 6)
+		offer := fmt.Sprintf("%d with %d dice", score, dice)
+		if event.Type == game.EventAccepted {
+			a.decide(i, event.Player, Accept, "accept "+offer, "reject", accept, reject)
+		} else {
+			a.decide(i, event.Player, Accept, "reject "+offer, "accept", reject, accept)
+		}
+	case game.EventRolled, game.EventBanked:
+		turn := g.Current().Current()
+		if turn == nil || turn.Dice() == nil || turn.State() != game.AwaitingRoll {
+			return
+		}
+		if event.Type == game.EventBanked {
+			a.bank = i
+		}
+		score, dice := turn.Result(), turn.Available()
+		best, value := a.solver.Keep(a.score, a.roll)
+		a.decide(i, event.Player, Keep,
+			"keep "+describe(a.roll, turn.Held()),
+			"keep "+describe(a.roll, best.Dice),
+			a.solver.Value(score, dice), value)
+		roll := a.solver.Roll(score, dice)
+		if event.Type == game.EventBanked {
+			a.decide(i, event.Player, Bank, fmt.Sprintf("bank %d", score), fmt.Sprintf("roll %d dice", dice), float64(score), roll)
+		} else {
+			a.decide(i, event.Player, Bank, fmt.Sprintf("roll %d dice", dice), fmt.Sprintf("bank %d", score), roll, float64(score))
+		}
+	case game.EventSkipped:
+		// a player skipped before deciding still uses up a turn
+		if !g.Current().Active() {
+			a.turn++
+		}
+	case game.EventUndone:
+		// a bank taken back is judged again with whatever settles it next,
+		// along with any decision made since
+		if turn := g.Current().Current(); turn != nil && turn.Dice() != nil {
+			return
+		}
+		if g.Current().Active() {
+			a.turn--
+		}
+		n := len(a.report.Decisions)
+		for n > 0 && a.report.Decisions[n-1].Event >= a.bank {
+			n--
+		}
+		a.report.Decisions = a.report.Decisions[:n]
+	}
+}
+
+func (a *analyzer) after(g *game.Game, event game.Event) {
+	switch event.Type {
+	case game.EventStarted:
+		a.turn = 1
+	case game.EventRolled:
+		if turn := g.Current().Current(); turn != nil {
+			a.score, a.roll = turn.Result(), turn.Dice()
+		}
+	}
+}
+
+func (a *analyzer) decide(event int, player string, kind Kind, chosen, best string, value, bestValue float64) {
+	a.report.Decisions = append(a.report.Decisions, Decision{
+		Event:  event,
+		Turn:   a.turn,
+		Player: player,
+		Kind:   kind,
+		Chosen: chosen,
+		Best:   best,
+		Loss:   max(bestValue-value, 0),
+	})
+}
+
+func describe(roll game.Roll, dice []int) string {
+	values := make([]string, len(dice))
+	for i, j := range dice {
+		values[i] = fmt.Sprint(roll[j])
+	}
+	return strings.Join(values, " ")
+}
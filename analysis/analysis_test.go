@@ -0,0 +1,78 @@
+package analysis_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ryannatesmith/farkle/analysis"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/notation"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+	solver := strategy.NewSolver()
+	record, err := notation.Parse(strings.NewReader(`[Target "10000"]
+[Player "a" "Alice"]
+[Player "b" "Bob"]
+
+1. a R111542 K0 B
+2. b A R24421 K4 R2345 K3 B
+3. a X R111542 K012 B U R254 K1 B
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := analysis.Analyze(record.Events(), solver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blunders := report.Blunders("a", 10)
+	if len(blunders) != 2 {
+		t.Fatalf("unexpected blunders %+v", blunders)
+	}
+	if blunders[0].Kind != analysis.Bank || blunders[0].Turn != 1 || blunders[0].Chosen != "bank 100" {
+		t.Errorf("worst blunder should be banking 100 with five dice, got %+v", blunders[0])
+	}
+	for _, d := range report.Decisions {
+		if d.Event == 15 {
+			t.Errorf("decisions settled by the bank taken back should be dropped: %+v", d)
+		}
+	}
+	if report.Loss("a") <= report.Loss("b") {
+		t.Errorf("alice should have lost more than bob: %v, %v", report.Loss("a"), report.Loss("b"))
+	}
+	var buf bytes.Buffer
+	if err := report.Write(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "turn 1: bank 100, better to roll 5 dice") {
+		t.Errorf("unexpected review:\n%s", buf.String())
+	}
+}
+
+func TestAnalyze_Optimal(t *testing.T) {
+	t.Parallel()
+	var events []game.Event
+	g := game.NewGame(
+		game.WithRandom(game.NewSeededRandom(5)),
+		game.WithTarget(3_000),
+		game.WithListener(func(e game.Event) { events = append(events, e) }),
+	)
+	g.Join("one")
+	g.Join("two")
+	if err := strategy.Play(g, strategy.Optimal(), strategy.Optimal()); err != nil {
+		t.Fatal(err)
+	}
+	report, err := analysis.Analyze(events, strategy.NewSolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range g.Players() {
+		if loss := report.Loss(player.ID()); loss > 0 {
+			t.Errorf("optimal play should lose nothing, %s lost %v: %+v", player.ID(), loss, report.Blunders(player.ID(), 3))
+		}
+	}
+}
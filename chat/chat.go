@@ -0,0 +1,336 @@
+// Package chat plays games in chat rooms. A Bot reads commands such as
+// "!farkle new", "!roll", "!keep 1 3" and "!bank" from a Transport, plays
+// them on the channel's game and replies with the dice and scores. Real
+// chat platforms implement Transport; Fake runs everything in process.
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Message is a line of text said in a channel
+type Message struct {
+	Channel string
+	// User identifies who said it, and is empty for the bot's replies
+	User string
+	Text string
+}
+
+// Transport connects a bot to a chat platform
+type Transport interface {
+	// Receive waits for the next message in any channel the bot is in,
+	// returning io.EOF once there will be no more
+	Receive(ctx context.Context) (Message, error)
+	Send(ctx context.Context, message Message) error
+}
+
+var (
+	ErrNoGame      = errors.New("no game in this channel, start one with !farkle new")
+	ErrGameRunning = errors.New("a game is already running in this channel")
+	ErrNotPlaying  = errors.New("you are not in this game, join with !farkle join")
+	ErrJoined      = errors.New("you have already joined")
+	ErrStarted     = errors.New("the game has already started")
+	ErrUnknown     = errors.New("unknown command, try !farkle help")
+)
+
+type Opt func(*Bot)
+
+// WithGameOpts sets the options every new game is created with
+func WithGameOpts(opts ...game.GameOpt) Opt {
+	return func(b *Bot) {
+		b.gameOpts = opts
+	}
+}
+
+// WithPrefix sets the text that starts every command, "!" by default
+func WithPrefix(prefix string) Opt {
+	return func(b *Bot) {
+		b.prefix = prefix
+	}
+}
+
+// table is a channel's game, which is only played once started
+type table struct {
+	game    *game.Game
+	started bool
+}
+
+type Bot struct {
+	mu       sync.Mutex
+	prefix   string
+	gameOpts []game.GameOpt
+	tables   map[string]*table
+}
+
+// Run answers messages from the transport until it runs out of them or
+// the context ends
+func (b *Bot) Run(ctx context.Context, transport Transport) error {
+	for {
+		message, err := transport.Receive(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reply := b.Handle(message)
+		if reply == "" {
+			continue
+		}
+		if err := transport.Send(ctx, Message{Channel: message.Channel, Text: reply}); err != nil {
+			return err
+		}
+	}
+}
+
+// Handle plays a command and returns the reply, which is empty for
+// messages that are not commands
+func (b *Bot) Handle(message Message) string {
+	text, ok := strings.CutPrefix(strings.TrimSpace(message.Text), b.prefix)
+	if !ok {
+		return ""
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	reply, err := b.command(message, strings.ToLower(fields[0]), fields[1:])
+	if err != nil {
+		return "@" + message.User + ": " + err.Error()
+	}
+	return reply
+}
+
+func (b *Bot) command(message Message, command string, args []string) (string, error) {
+	t := b.tables[message.Channel]
+	if command == "farkle" {
+		sub := "help"
+		if len(args) > 0 {
+			sub = strings.ToLower(args[0])
+		}
+		return b.manage(message, t, sub)
+	}
+	if t == nil {
+		return "", ErrNoGame
+	}
+	if !t.started {
+		return "", fmt.Errorf("the game has not started, start it with %sfarkle start", b.prefix)
+	}
+	g := t.game
+	player := g.Current()
+	if command == "undo" {
+		player = undoing(g)
+	}
+	if player.ID() != message.User {
+		return "", game.ErrNotYourTurn
+	}
+	var err error
+	switch command {
+	case "accept":
+		err = g.Accept()
+	case "reject":
+		err = g.Reject()
+	case "roll":
+		// rolling without deciding turns down the previous player's dice
+		if g.State() == game.AwaitingDecision {
+			if err := rollable(g.Position()); err != nil {
+				return "", err
+			}
+			if err := g.Reject(); err != nil {
+				return "", err
+			}
+		}
+		err = g.Roll()
+	case "keep":
+		var dice []int
+		dice, err = parseKeep(args)
+		if err == nil {
+			err = g.Keep(dice...)
+		}
+	case "bank":
+		err = g.Bank()
+	case "undo":
+		err = g.Undo()
+	default:
+		return "", ErrUnknown
+	}
+	if err != nil {
+		return "", err
+	}
+	return b.after(message.Channel, t, command), nil
+}
+
+// undoing returns the player whose keep or bank an undo would take back:
+// the current player once they have rolled, otherwise the previous player
+func undoing(g *game.Game) *game.Player {
+	if turn := g.Current().Current(); turn != nil && turn.Dice() != nil {
+		return g.Current()
+	}
+	return g.Players()[lastSeat(g)]
+}
+
+// rollable returns why the current player can't reject the previous
+// player's dice and roll, trying both on the position so that neither is
+// taken unless both can be
+func rollable(position game.Position) error {
+	rejected, err := game.Apply(position, game.Action{Type: game.EventRejected})
+	if err != nil {
+		return err
+	}
+	dice := slices.Repeat(game.Roll{1}, rejected.Available())
+	_, err = game.Apply(rejected, game.Action{Type: game.EventRolled, Dice: dice})
+	return err
+}
+
+// manage handles the !farkle commands that create, join and show games
+func (b *Bot) manage(message Message, t *table, sub string) (string, error) {
+	switch sub {
+	case "help":
+		return Help(b.prefix), nil
+	case "new":
+		if t != nil && !t.game.Over() {
+			return "", ErrGameRunning
+		}
+		t = &table{game: game.NewGame(b.gameOpts...)}
+		t.game.Join(message.User, game.WithID(message.User))
+		b.tables[message.Channel] = t
+		return fmt.Sprintf("%s started a game of Farkle to %d. Join with %sfarkle join, then %sfarkle start.", message.User, t.game.Target(), b.prefix, b.prefix), nil
+	}
+	if t == nil {
+		return "", ErrNoGame
+	}
+	switch sub {
+	case "join":
+		if t.started {
+			return "", ErrStarted
+		}
+		for _, player := range t.game.Players() {
+			if player.ID() == message.User {
+				return "", ErrJoined
+			}
+		}
+		t.game.Join(message.User, game.WithID(message.User))
+		return fmt.Sprintf("%s joined. %d players.", message.User, len(t.game.Players())), nil
+	case "start":
+		if t.started {
+			return "", ErrStarted
+		}
+		if !joined(t.game, message.User) {
+			return "", ErrNotPlaying
+		}
+		if err := t.game.Start(); err != nil {
+			return "", err
+		}
+		t.started = true
+		return fmt.Sprintf("Game on! @%s, %sroll to begin.", t.game.Current().ID(), b.prefix), nil
+	case "score":
+		return Scoreboard(t.game), nil
+	default:
+		return "", ErrUnknown
+	}
+}
+
+// after describes the game following a successful action
+func (b *Bot) after(channel string, t *table, command string) string {
+	g := t.game
+	var lines []string
+	player := g.Current()
+	turn := player.Current()
+	if command == "roll" {
+		last := g.Players()[lastSeat(g)]
+		if turn == nil || turn.Farkle() {
+			// the roll ended the turn, so show the turn of whoever rolled
+			turns := last.Turns()
+			lines = append(lines, fmt.Sprintf("%s rolled %s FARKLE!", last.ID(), Dice(turns[len(turns)-1].Dice())))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s rolled %s", player.ID(), Dice(turn.Dice())))
+		}
+	}
+	switch command {
+	case "keep", "undo":
+		if turn != nil {
+			lines = append(lines, fmt.Sprintf("%s has %d this turn with %d dice to roll. %sroll or %sbank?", player.ID(), turn.Result(), turn.Available(), b.prefix, b.prefix))
+		}
+	case "bank":
+		last := g.Players()[lastSeat(g)]
+		turns := last.Turns()
+		lines = append(lines, fmt.Sprintf("%s banked %d.", last.ID(), turns[len(turns)-1].Result()))
+	}
+	if g.Over() {
+		delete(b.tables, channel)
+		winner := g.Winner()
+		lines = append(lines, Scoreboard(g), fmt.Sprintf("🏆 %s wins with %d!", winner.ID(), g.Standing(winner)))
+		return strings.Join(lines, "\n")
+	}
+	if g.State() == game.AwaitingDecision {
+		lines = append(lines, Scoreboard(g))
+		if dice, score := g.Offer(); dice > 0 {
+			lines = append(lines, fmt.Sprintf("@%s: %saccept %d dice for %d, or %sroll to start fresh.", player.ID(), b.prefix, dice, score, b.prefix))
+		} else {
+			lines = append(lines, fmt.Sprintf("@%s: your turn, %sroll.", player.ID(), b.prefix))
+		}
+	} else if command == "accept" || command == "reject" {
+		lines = append(lines, fmt.Sprintf("%s starts on %d with %d dice. %sroll!", player.ID(), turn.Result(), turn.Available(), b.prefix))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lastSeat returns the seat of the player before the current one
+func lastSeat(g *game.Game) int {
+	n := len(g.Players())
+	for seat, player := range g.Players() {
+		if player == g.Current() {
+			return (seat + n - 1) % n
+		}
+	}
+	return 0
+}
+
+func joined(g *game.Game, user string) bool {
+	for _, player := range g.Players() {
+		if player.ID() == user {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKeep reads die positions counted from one
+func parseKeep(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: say which dice to keep, counting from 1", game.ErrInvalidKeep)
+	}
+	dice := make([]int, 0, len(args))
+	for _, arg := range args {
+		for _, field := range strings.Split(arg, ",") {
+			if field == "" {
+				continue
+			}
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a die", game.ErrInvalidKeep, field)
+			}
+			dice = append(dice, n-1)
+		}
+	}
+	return dice, nil
+}
+
+func New(opts ...Opt) *Bot {
+	b := &Bot{prefix: "!", tables: make(map[string]*table)}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
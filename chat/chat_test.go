@@ -0,0 +1,67 @@
+package chat_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ryannatesmith/farkle/chat"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+func TestBot(t *testing.T) {
+	t.Parallel()
+	bot := chat.New(chat.WithGameOpts(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6).Roll), game.WithTarget(300)))
+	fake := chat.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- bot.Run(ctx, fake) }()
+	type step struct {
+		user string
+		text string
+		want []string
+	}
+	for _, s := range []step{
+		{user: "bob", text: "!roll", want: []string{"@bob: no game in this channel"}},
+		{user: "alice", text: "!farkle new", want: []string{"alice started a game of Farkle to 300"}},
+		{user: "bob", text: "hello everyone"},
+		{user: "bob", text: "!farkle join", want: []string{"bob joined. 2 players."}},
+		{user: "bob", text: "!farkle join", want: []string{"@bob: you have already joined"}},
+		{user: "alice", text: "!farkle start", want: []string{"Game on! @alice"}},
+		{user: "bob", text: "!roll", want: []string{"@bob: not your turn"}},
+		{user: "alice", text: "!roll", want: []string{"alice rolled 1:⚀ 2:⚀ 3:⚀ 4:⚄ 5:⚃ 6:⚁"}},
+		{user: "alice", text: "!keep 5", want: []string{"@alice: invalid keep"}},
+		{user: "alice", text: "!keep 1 2 3 4", want: []string{"alice has 350 this turn with 2 dice to roll"}},
+		{user: "alice", text: "!bank", want: []string{"alice banked 350.", "▶ bob 0", "@bob: !accept 2 dice for 350"}},
+		{user: "bob", text: "!undo", want: []string{"@bob: not your turn"}},
+		{user: "alice", text: "!undo", want: []string{"alice has 350 this turn with 2 dice to roll"}},
+		{user: "alice", text: "!bank", want: []string{"alice banked 350.", "@bob: !accept 2 dice for 350"}},
+		{user: "bob", text: "!roll", want: []string{"bob rolled 1:⚃ 2:⚂ 3:⚁ 4:⚂ 5:⚃ 6:⚅ FARKLE!", "🏆 alice wins with 350!"}},
+		{user: "alice", text: "!bank", want: []string{"@alice: no game in this channel"}},
+	} {
+		fake.Post("#games", s.user, s.text)
+		if s.want == nil {
+			continue
+		}
+		reply := <-fake.Replies()
+		if reply.Channel != "#games" {
+			t.Errorf("reply to %q in channel %q", s.text, reply.Channel)
+		}
+		for _, want := range s.want {
+			if !strings.Contains(reply.Text, want) {
+				t.Errorf("reply to %s %q missing %q:\n%s", s.user, s.text, want, reply.Text)
+			}
+		}
+	}
+	fake.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case reply := <-fake.Replies():
+		t.Errorf("unexpected reply %q", reply.Text)
+	default:
+	}
+}
@@ -0,0 +1,56 @@
+package chat
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Fake is a transport held in memory, for playing and testing without a
+// chat platform. Post says something as a user and Replies hears the bot.
+type Fake struct {
+	incoming chan Message
+	replies  chan Message
+	once     sync.Once
+}
+
+// Post says text in a channel as the user
+func (f *Fake) Post(channel, user, text string) {
+	f.incoming <- Message{Channel: channel, User: user, Text: text}
+}
+
+// Close ends the conversation, stopping the bot once it has answered
+// everything posted
+func (f *Fake) Close() {
+	f.once.Do(func() { close(f.incoming) })
+}
+
+// Replies returns the bot's messages in the order they were sent
+func (f *Fake) Replies() <-chan Message {
+	return f.replies
+}
+
+func (f *Fake) Receive(ctx context.Context) (Message, error) {
+	select {
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	case message, ok := <-f.incoming:
+		if !ok {
+			return Message{}, io.EOF
+		}
+		return message, nil
+	}
+}
+
+func (f *Fake) Send(ctx context.Context, message Message) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case f.replies <- message:
+		return nil
+	}
+}
+
+func NewFake() *Fake {
+	return &Fake{incoming: make(chan Message), replies: make(chan Message, 64)}
+}
@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// faces are the die emoji, from one to six
+var faces = []string{"⚀", "⚁", "⚂", "⚃", "⚄", "⚅"}
+
+// Dice renders a roll as die emoji, each numbered for keeping
+func Dice(roll game.Roll) string {
+	parts := make([]string, len(roll))
+	for i, die := range roll {
+		face := "?"
+		if die >= 1 && int(die) <= len(faces) {
+			face = faces[die-1]
+		}
+		parts[i] = fmt.Sprintf("%d:%s", i+1, face)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Scoreboard renders every player's standing in turn order, marking whose
+// turn it is and any handicap
+func Scoreboard(g *game.Game) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Scores (to %d):", g.Target())
+	for _, player := range g.Players() {
+		marker := "  "
+		if !g.Over() && player == g.Current() {
+			marker = "▶ "
+		}
+		fmt.Fprintf(&b, "\n%s%s %d", marker, player.ID(), player.Score())
+		if team := player.Team(); team != "" {
+			fmt.Fprintf(&b, " (%s %d)", team, g.Standing(player))
+		}
+		if h := player.Handicap(); h != (game.Handicap{}) {
+			fmt.Fprintf(&b, " [handicap +%d start, -%d opening, -%d target]", h.Start, h.Opening, h.Target)
+		}
+	}
+	return b.String()
+}
+
+// Help lists the commands, each starting with prefix
+func Help(prefix string) string {
+	return strings.Join([]string{
+		prefix + "farkle new: start a game in this channel",
+		prefix + "farkle join: join the game",
+		prefix + "farkle start: start playing",
+		prefix + "farkle score: show the scores",
+		prefix + "roll: roll your dice",
+		prefix + "keep 1 3: keep the first and third dice",
+		prefix + "bank: bank your turn",
+		prefix + "accept / " + prefix + "reject: take or refuse the dice left to you",
+		prefix + "undo: take back your last keep or bank",
+	}, "\n")
+}
@@ -0,0 +1,191 @@
+// Command farkle-arena pits strategies against each other over many
+// seeded games and reports how often each wins.
+//
+//	farkle-arena -n 10000 -seed 1 threshold:300:3 threshold:1000:2
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+type config struct {
+	games  int
+	seed   uint64
+	target uint
+	check  int
+	z      float64
+	json   bool
+	specs  []string
+}
+
+type result struct {
+	Strategy string  `json:"strategy"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"winRate"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+	Margin   float64 `json:"margin"`
+}
+
+type report struct {
+	Games        int      `json:"games"`
+	StoppedEarly bool     `json:"stoppedEarly"`
+	AverageTurns float64  `json:"averageTurns"`
+	Results      []result `json:"results"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	var cfg config
+	flags := flag.NewFlagSet("farkle-arena", flag.ContinueOnError)
+	flags.IntVar(&cfg.games, "n", 1_000, "maximum number of games to play")
+	flags.Uint64Var(&cfg.seed, "seed", 1, "seed for the dice of the first game")
+	flags.UintVar(&cfg.target, "target", 10_000, "target score of each game")
+	flags.IntVar(&cfg.check, "check", 100, "games between significance checks, or 0 to play every game")
+	flags.Float64Var(&cfg.z, "z", 3, "z-score at which the leader is significantly better")
+	flags.BoolVar(&cfg.json, "json", false, "write the report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg.specs = flags.Args()
+	if len(cfg.specs) < 2 {
+		return fmt.Errorf("at least two strategies are needed")
+	}
+	if cfg.games < 1 {
+		return fmt.Errorf("-n must be at least 1")
+	}
+	if cfg.target < 1 || cfg.target > math.MaxUint32 {
+		return fmt.Errorf("-target must be between 1 and %d", uint32(math.MaxUint32))
+	}
+	r, err := arena(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.json {
+		return json.NewEncoder(w).Encode(r)
+	}
+	return write(w, r)
+}
+
+// arena plays games until the limit is reached or the leading strategy
+// wins significantly more often than the others. Seats rotate every game.
+func arena(cfg config) (*report, error) {
+	bots := make([]strategy.Strategy, len(cfg.specs))
+	for i, spec := range cfg.specs {
+		bot, err := strategy.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		bots[i] = bot
+	}
+	wins := make([]int, len(bots))
+	margins := make([]int64, len(bots))
+	r := &report{}
+	var turns int
+	for r.Games < cfg.games {
+		g := game.NewGame(
+			game.WithRandom(game.NewSeededRandom(cfg.seed+uint64(r.Games))),
+			game.WithTarget(uint32(cfg.target)),
+			game.WithoutUndo(),
+		)
+		seats := make([]strategy.Strategy, len(bots))
+		for i := range bots {
+			seat := (i + r.Games) % len(bots)
+			seats[seat] = bots[i]
+		}
+		for seat := range seats {
+			g.Join(seats[seat].Name())
+		}
+		if err := strategy.Play(g, seats...); err != nil {
+			return nil, fmt.Errorf("game %d: %w", r.Games, err)
+		}
+		r.Games++
+		scores := make([]int64, len(bots))
+		for i := range bots {
+			player := g.Players()[(i+r.Games-1)%len(bots)]
+			scores[i] = int64(player.Score())
+			turns += len(player.Turns())
+			if player == g.Winner() {
+				wins[i]++
+			}
+		}
+		for i := range bots {
+			var best int64
+			for j, score := range scores {
+				if j != i {
+					best = max(best, score)
+				}
+			}
+			margins[i] += scores[i] - best
+		}
+		if cfg.check > 0 && r.Games%cfg.check == 0 && significant(wins, r.Games, cfg.z) {
+			r.StoppedEarly = true
+			break
+		}
+	}
+	r.AverageTurns = float64(turns) / float64(r.Games)
+	for i, bot := range bots {
+		low, high := wilson(wins[i], r.Games, 1.96)
+		r.Results = append(r.Results, result{
+			Strategy: bot.Name(),
+			Wins:     wins[i],
+			WinRate:  float64(wins[i]) / float64(r.Games),
+			Low:      low,
+			High:     high,
+			Margin:   float64(margins[i]) / float64(r.Games),
+		})
+	}
+	return r, nil
+}
+
+// significant reports whether the leader's wins are z standard deviations
+// above what would be expected if every strategy were equally strong
+func significant(wins []int, games int, z float64) bool {
+	leader := 0
+	for _, w := range wins {
+		leader = max(leader, w)
+	}
+	p := 1 / float64(len(wins))
+	return (float64(leader)-p*float64(games))/math.Sqrt(float64(games)*p*(1-p)) >= z
+}
+
+// wilson returns the Wilson score interval of a win rate
+func wilson(wins, games int, z float64) (float64, float64) {
+	n := float64(games)
+	p := float64(wins) / n
+	centre := (p + z*z/(2*n)) / (1 + z*z/n)
+	spread := z * math.Sqrt(p*(1-p)/n+z*z/(4*n*n)) / (1 + z*z/n)
+	return centre - spread, centre + spread
+}
+
+func write(w io.Writer, r *report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "strategy\twins\twin rate\t95% CI\tmargin\t")
+	for _, res := range r.Results {
+		fmt.Fprintf(tw, "%s\t%d\t%.3f\t%.3f-%.3f\t%+.0f\t\n", res.Strategy, res.Wins, res.WinRate, res.Low, res.High, res.Margin)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	stopped := ""
+	if r.StoppedEarly {
+		stopped = ", stopped early"
+	}
+	_, err := fmt.Fprintf(w, "%d games, %.1f turns per game%s\n", r.Games, r.AverageTurns, stopped)
+	return err
+}
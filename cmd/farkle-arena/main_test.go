@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		args    []string
+		games   int
+		stopped bool
+		err     bool
+	}
+	for _, c := range []testCase{
+		{
+			name:  "plays every game",
+			args:  []string{"-json", "-n", "20", "-check", "0", "threshold:300:3", "threshold:500:2"},
+			games: 20,
+		},
+		{
+			name:    "stops once significant",
+			args:    []string{"-json", "-n", "5000", "-target", "2000", "threshold:300:3", "threshold:5000:1"},
+			games:   100,
+			stopped: true,
+		},
+		{
+			name: "one strategy",
+			args: []string{"threshold:300:3"},
+			err:  true,
+		},
+		{
+			name: "unknown strategy",
+			args: []string{"threshold:300:3", "reckless"},
+			err:  true,
+		},
+		{
+			name: "no games",
+			args: []string{"-n", "0", "threshold:300:3", "threshold:500:2"},
+			err:  true,
+		},
+		{
+			name: "target out of range",
+			args: []string{"-target", "4294967296", "threshold:300:3", "threshold:500:2"},
+			err:  true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			err := run(c.args, &buf)
+			if !cmp.Equal(c.err, err != nil) {
+				t.Fatal("unexpected error", err)
+			}
+			if err != nil {
+				return
+			}
+			var got report
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Games != c.games || got.StoppedEarly != c.stopped {
+				t.Errorf("unexpected report %+v", got)
+			}
+			var wins int
+			for _, res := range got.Results {
+				wins += res.Wins
+				if res.Low > res.WinRate || res.High < res.WinRate {
+					t.Errorf("win rate %v outside interval %v-%v", res.WinRate, res.Low, res.High)
+				}
+			}
+			if wins != got.Games {
+				t.Errorf("wins: +want -got\n\t+%d\n\t-%d", got.Games, wins)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Command farkle-telnet serves games over plain TCP, for playing on a LAN
+// with telnet or netcat. Players pick a name, join a lobby and play with
+// the chat commands, without the "!" prefix.
+//
+//	farkle-telnet -addr :2323 -target 10000
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("farkle-telnet", flag.ContinueOnError)
+	addr := flags.String("addr", ":2323", "address to listen on")
+	target := flags.Uint("target", 10_000, "target score of each game")
+	noUndo := flags.Bool("no-undo", false, "disable undo")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *target < 1 || *target > math.MaxUint32 {
+		return fmt.Errorf("-target must be between 1 and %d", uint32(math.MaxUint32))
+	}
+	opts := []game.GameOpt{game.WithTarget(uint32(*target))}
+	if *noUndo {
+		opts = append(opts, game.WithoutUndo())
+	}
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "listening on %s\n", listener.Addr())
+	return newServer(opts...).serve(listener)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// player is the far end of a connection to the server
+type player struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func connect(t *testing.T, s *server, name string) *player {
+	t.Helper()
+	client, conn := net.Pipe()
+	go s.handle(conn)
+	p := &player{conn: client, reader: bufio.NewReader(client)}
+	t.Cleanup(func() { client.Close() })
+	p.expect(t, "What's your name?")
+	p.say(t, name)
+	p.expect(t, "Hi "+name)
+	return p
+}
+
+func (p *player) say(t *testing.T, line string) {
+	t.Helper()
+	if _, err := p.conn.Write([]byte(line + "\r\n")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expect reads lines until one contains want
+func (p *player) expect(t *testing.T, want string) {
+	t.Helper()
+	_ = p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var seen []string
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("waiting for %q: %v after:\n%s", want, err, strings.Join(seen, ""))
+		}
+		if strings.Contains(line, want) {
+			return
+		}
+		seen = append(seen, line)
+	}
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	s := newServer(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6).Roll), game.WithTarget(300))
+	alice := connect(t, s, "alice")
+	bob := connect(t, s, "bob")
+	client, conn := net.Pipe()
+	go s.handle(conn)
+	defer client.Close()
+	imposter := &player{conn: client, reader: bufio.NewReader(client)}
+	imposter.say(t, "alice")
+	imposter.expect(t, "alice is taken.")
+
+	alice.say(t, "roll")
+	alice.expect(t, "Join a lobby first")
+	alice.say(t, "join den")
+	alice.expect(t, "alice entered den.")
+	alice.say(t, "farkle new")
+	alice.expect(t, "alice started a game of Farkle to 300")
+	bob.say(t, "lobbies")
+	bob.expect(t, "den (alice)")
+	bob.say(t, "join den")
+	bob.expect(t, "bob entered den.")
+	alice.expect(t, "bob entered den.")
+	bob.say(t, "farkle join")
+	alice.expect(t, "bob joined. 2 players.")
+	alice.say(t, "farkle start")
+	bob.expect(t, "Game on! @alice")
+	alice.say(t, "roll")
+	bob.expect(t, "alice rolled 1:⚀ 2:⚀ 3:⚀ 4:⚄ 5:⚃ 6:⚁")
+	alice.say(t, "keep 1 2 3 4")
+	alice.say(t, "bank")
+	bob.expect(t, "alice banked 350.")
+	bob.expect(t, "@bob: accept 2 dice for 350")
+	bob.say(t, "say good game")
+	alice.expect(t, "<bob> good game")
+	bob.say(t, "roll")
+	alice.expect(t, "FARKLE!")
+	alice.expect(t, "alice wins with 350!")
+	bob.say(t, "quit")
+	bob.expect(t, "Bye!")
+	alice.expect(t, "bob left.")
+}
+
+func TestServer_FallenBehind(t *testing.T) {
+	t.Parallel()
+	s := newServer()
+	alice := connect(t, s, "alice")
+	bob := connect(t, s, "bob")
+	alice.say(t, "join den")
+	alice.expect(t, "alice entered den.")
+	bob.say(t, "join den")
+	bob.expect(t, "bob entered den.")
+	alice.expect(t, "bob entered den.")
+	// bob stops reading, so his lines back up until he is hung up on. Each
+	// is too long to buffer, leaving one being written and outbox queued.
+	for i := range outbox + 2 {
+		line := fmt.Sprintf("line %d %s", i, strings.Repeat("x", 4096))
+		alice.say(t, "say "+line)
+		alice.expect(t, line)
+	}
+	alice.expect(t, "bob left.")
+	_ = bob.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.Copy(io.Discard, bob.reader); err != nil {
+		t.Errorf("still connected after falling behind: %v", err)
+	}
+}
+
+func TestRun_Target(t *testing.T) {
+	t.Parallel()
+	for _, target := range []string{"0", "4294967296"} {
+		if err := run([]string{"-addr", "127.0.0.1:0", "-target", target}, io.Discard); err == nil {
+			t.Errorf("-target %s: no error", target)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/chat"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// outbox is the number of lines a connection can fall behind by before
+// it is hung up on
+const outbox = 256
+
+// server holds every lobby. Each lobby has its own bot and lock, so
+// games are played independently of each other.
+type server struct {
+	mu       sync.Mutex
+	gameOpts []game.GameOpt
+	names    map[string]bool
+	lobbies  map[string]*lobby
+}
+
+// lobby is a room with at most one game at a time
+type lobby struct {
+	name string
+	bot  *chat.Bot
+	// playing keeps each command and its broadcast together, so everyone
+	// hears replies in the order commands were played
+	playing sync.Mutex
+	mu      sync.Mutex
+	members []*client
+}
+
+// client is a connection. Lines to it are queued and written by its own
+// goroutine, so a slow reader never holds up a game.
+type client struct {
+	name  string
+	out   chan string
+	lobby *lobby
+	// hangUp closes the connection, ending its session
+	hangUp func()
+}
+
+func (s *server) serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle talks to one connection until it quits or hangs up
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+	c := &client{out: make(chan string, outbox), hangUp: sync.OnceFunc(func() { _ = conn.Close() })}
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		w := bufio.NewWriter(conn)
+		// errors are ignored so that the queue keeps draining after a
+		// hang up, until the reader notices
+		for line := range c.out {
+			_, _ = w.WriteString(line + "\r\n")
+			if len(c.out) == 0 {
+				_ = w.Flush()
+			}
+		}
+		_ = w.Flush()
+	}()
+	defer func() {
+		s.leave(c)
+		s.forget(c.name)
+		close(c.out)
+		<-written
+	}()
+	scanner := bufio.NewScanner(conn)
+	c.send("Welcome to Farkle! What's your name?")
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if c.name == "" {
+			if err := s.name(c, line); err != nil {
+				c.send(err.Error() + ". What's your name?")
+				continue
+			}
+			c.send(fmt.Sprintf("Hi %s. %s", c.name, s.list()))
+			c.send("Type join <lobby> to enter or create one, or help.")
+			continue
+		}
+		if !s.command(c, line) {
+			return
+		}
+	}
+}
+
+// command handles a line from a named client, returning false once they quit
+func (s *server) command(c *client, line string) bool {
+	command, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+	switch strings.ToLower(command) {
+	case "quit":
+		c.send("Bye!")
+		return false
+	case "lobbies":
+		c.send(s.list())
+	case "join":
+		if rest == "" {
+			c.send("join which lobby?")
+			break
+		}
+		s.leave(c)
+		s.enter(c, rest)
+	case "leave":
+		if c.lobby == nil {
+			c.send("You are not in a lobby.")
+			break
+		}
+		s.leave(c)
+		c.send(s.list())
+	case "say":
+		if c.lobby == nil {
+			c.send("Join a lobby to talk.")
+			break
+		}
+		c.lobby.broadcast(fmt.Sprintf("<%s> %s", c.name, rest))
+	case "help":
+		c.send("lobbies, join <lobby>, leave, say <text>, quit. In a lobby:")
+		c.send(chat.Help(""))
+	default:
+		if c.lobby == nil {
+			c.send("Join a lobby first: join <lobby>")
+			break
+		}
+		c.lobby.play(c, line)
+	}
+	return true
+}
+
+// name claims a name for the client, which must be unique on the server
+func (s *server) name(c *client, name string) error {
+	if strings.ContainsAny(name, " \t") {
+		return errors.New("names cannot contain spaces")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.names[name] {
+		return fmt.Errorf("%s is taken", name)
+	}
+	s.names[name] = true
+	c.name = name
+	return nil
+}
+
+func (s *server) forget(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.names, name)
+}
+
+// list describes the lobbies and who is in them
+func (s *server) list() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.lobbies) == 0 {
+		return "No lobbies yet."
+	}
+	names := make([]string, 0, len(s.lobbies))
+	for name := range s.lobbies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	lines := []string{"Lobbies:"}
+	for _, name := range names {
+		lines = append(lines, "  "+s.lobbies[name].describe())
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// enter puts the client in a lobby, creating it if needed. The server's
+// lock is held throughout, and then the lobby's, so that the lobby can't
+// be closed by the last member leaving before the client is in it.
+func (s *server) enter(c *client, name string) {
+	s.mu.Lock()
+	l, ok := s.lobbies[name]
+	if !ok {
+		l = &lobby{name: name, bot: chat.New(chat.WithPrefix(""), chat.WithGameOpts(s.gameOpts...))}
+		s.lobbies[name] = l
+	}
+	l.mu.Lock()
+	l.members = append(l.members, c)
+	l.mu.Unlock()
+	s.mu.Unlock()
+	c.lobby = l
+	l.broadcast(fmt.Sprintf("%s entered %s.", c.name, name))
+	if !ok {
+		c.send("Start a game with: farkle new")
+	}
+}
+
+// leave takes the client out of their lobby, closing it once empty
+func (s *server) leave(c *client) {
+	l := c.lobby
+	if l == nil {
+		return
+	}
+	c.lobby = nil
+	s.mu.Lock()
+	l.mu.Lock()
+	l.members = slices.DeleteFunc(l.members, func(m *client) bool { return m == c })
+	empty := len(l.members) == 0
+	l.mu.Unlock()
+	if empty {
+		delete(s.lobbies, l.name)
+	}
+	s.mu.Unlock()
+	if empty {
+		return
+	}
+	l.broadcast(fmt.Sprintf("%s left.", c.name))
+}
+
+// play passes a game command to the lobby's bot, broadcasting the reply
+// to everyone in the lobby
+func (l *lobby) play(c *client, line string) {
+	l.playing.Lock()
+	defer l.playing.Unlock()
+	reply := l.bot.Handle(chat.Message{Channel: l.name, User: c.name, Text: line})
+	if reply != "" {
+		l.broadcast(reply)
+	}
+}
+
+func (l *lobby) broadcast(text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, member := range l.members {
+		member.send(text)
+	}
+}
+
+func (l *lobby) describe() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, len(l.members))
+	for i, member := range l.members {
+		names[i] = member.name
+	}
+	return fmt.Sprintf("%s (%s)", l.name, strings.Join(names, ", "))
+}
+
+// send queues text for the client, a line at a time, hanging up on them
+// if they have fallen too far behind to take it
+func (c *client) send(text string) {
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		select {
+		case c.out <- line:
+		default:
+			c.hangUp()
+			return
+		}
+	}
+}
+
+func newServer(opts ...game.GameOpt) *server {
+	return &server{gameOpts: opts, names: make(map[string]bool), lobbies: make(map[string]*lobby)}
+}
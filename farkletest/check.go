@@ -0,0 +1,86 @@
+package farkletest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Check returns every way the game breaks the invariants that hold after
+// any sequence of actions, or nil
+func Check(g *game.Game) error {
+	players := g.Players()
+	if len(players) == 0 {
+		return nil
+	}
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	current := g.Current()
+	if !slices.Contains(players, current) {
+		fail("current player %q is not playing", current.ID())
+	}
+	if dice, _ := g.Offer(); dice < 0 || dice > 6 {
+		fail("offer of %d dice", dice)
+	}
+	snapshot, position := g.Snapshot(), g.Position()
+	switch seat := position.Current(); {
+	case position.Players() != len(players):
+		fail("position has %d players, not %d", position.Players(), len(players))
+	case players[seat] != current:
+		fail("position has seat %d to play, not player %q", seat, current.ID())
+	}
+	for i, player := range players {
+		if player.Active() && (player != current || g.Over()) {
+			fail("player %q has a turn in progress out of turn", player.ID())
+		}
+		sum := player.Handicap().Start
+		for j, turn := range player.Turns() {
+			switch {
+			case turn.Farkle() && turn.Result() != 0:
+				fail("player %q farkled turn %d scoring %d", player.ID(), j+1, turn.Result())
+			case !turn.Farkle() && !turn.Banked():
+				fail("player %q turn %d was neither banked nor farkled", player.ID(), j+1)
+			}
+			sum += turn.Result()
+		}
+		if player.Score() != sum {
+			fail("player %q scored %d from turns adding up to %d", player.ID(), player.Score(), sum)
+		}
+		if got := snapshot.Players[i].Score; got != player.Score() {
+			fail("snapshot has player %q on %d, not %d", player.ID(), got, player.Score())
+		}
+		if i < position.Players() && position.Score(i) != player.Score() {
+			fail("position has player %q on %d, not %d", player.ID(), position.Score(i), player.Score())
+		}
+		if turn := player.Current(); turn != nil {
+			errs = append(errs, checkTurn(player, turn)...)
+		}
+	}
+	if g.Over() && g.Winner() == nil {
+		fail("game is over without a winner")
+	}
+	return errors.Join(errs...)
+}
+
+// checkTurn checks a turn in progress
+func checkTurn(player *game.Player, turn *game.Turn) []error {
+	var errs []error
+	if turn.Banked() || turn.Farkle() {
+		errs = append(errs, fmt.Errorf("player %q has a finished turn in progress", player.ID()))
+	}
+	if a := turn.Available(); a < 1 || a > 6 {
+		errs = append(errs, fmt.Errorf("player %q has %d dice to roll", player.ID(), a))
+	}
+	held := turn.Held()
+	for i, idx := range held {
+		if idx < 0 || idx >= len(turn.Dice()) || slices.Contains(held[:i], idx) {
+			errs = append(errs, fmt.Errorf("player %q holds dice %v of roll %v", player.ID(), held, turn.Dice()))
+			break
+		}
+	}
+	return errs
+}
@@ -0,0 +1,70 @@
+package farkletest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Dice is a queue of scripted dice, rolled in the order they were pushed.
+// Its Roll method is a game.Random.
+type Dice struct {
+	mu     sync.Mutex
+	queue  []uint8
+	rolled int
+}
+
+// Push adds dice to the end of the queue
+func (d *Dice) Push(dice ...uint8) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = append(d.queue, dice...)
+}
+
+// Len returns the number of dice left in the queue
+func (d *Dice) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.queue)
+}
+
+// Roll takes the next die from the queue. It panics once the queue is
+// empty, as a test that runs out of dice has gone wrong.
+func (d *Dice) Roll() uint8 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queue) == 0 {
+		panic(fmt.Sprintf("farkletest: no scripted dice left after rolling %d", d.rolled))
+	}
+	die := d.queue[0]
+	d.queue = d.queue[1:]
+	d.rolled++
+	return die
+}
+
+// NewDice returns a queue of the dice
+func NewDice(dice ...uint8) *Dice {
+	return &Dice{queue: dice}
+}
+
+// Cycle rolls the dice in order, starting again from the first once they
+// are used up. It panics without any dice to roll.
+func Cycle(dice ...uint8) game.Random {
+	if len(dice) == 0 {
+		panic("farkletest: no dice to cycle through")
+	}
+	i := 0
+	return func() uint8 {
+		d := dice[i%len(dice)]
+		i++
+		return d
+	}
+}
+
+// Constant always rolls the same die
+func Constant(die uint8) game.Random {
+	return func() uint8 {
+		return die
+	}
+}
@@ -0,0 +1,44 @@
+package farkletest
+
+import (
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Recorder keeps every event of the games it listens to
+type Recorder struct {
+	mu     sync.Mutex
+	events []game.Event
+}
+
+// Listen records the event
+func (r *Recorder) Listen(event game.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// Option returns the option that has a game report its events to the
+// recorder
+func (r *Recorder) Option() game.GameOpt {
+	return game.WithListener(r.Listen)
+}
+
+// Events returns the events recorded so far
+func (r *Recorder) Events() []game.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]game.Event(nil), r.events...)
+}
+
+// Types returns the type of each event recorded so far
+func (r *Recorder) Types() []game.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]game.EventType, len(r.events))
+	for i, event := range r.events {
+		ret[i] = event.Type
+	}
+	return ret
+}
@@ -0,0 +1,102 @@
+// Package farkletest helps test code built on games. A Harness plays a game
+// with scripted dice, from a compact script such as
+//
+//	roll 1 1 1 5 4 2; keep 0 1 2 3; bank
+//
+// checking the game's invariants after every script and recording its
+// events to assert on.
+package farkletest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Harness is a game played with scripted dice in a test
+type Harness struct {
+	Game   *game.Game
+	Dice   *Dice
+	Events *Recorder
+	tb     testing.TB
+	seen   int
+}
+
+// Start joins the players, their names also being their IDs, and starts
+// the game
+func (h *Harness) Start(names ...string) {
+	h.tb.Helper()
+	for _, name := range names {
+		h.Game.Join(name, game.WithID(name))
+	}
+	if err := h.Game.Start(); err != nil {
+		h.tb.Fatalf("start: %v", err)
+	}
+}
+
+// Play performs the script, failing the test if any action is refused or
+// the game breaks an invariant
+func (h *Harness) Play(script string) {
+	h.tb.Helper()
+	if err := Play(h.Game, h.Dice, script); err != nil {
+		h.tb.Fatal(err)
+	}
+	h.Check()
+}
+
+// Refuse performs the script, failing the test unless its last action is
+// refused
+func (h *Harness) Refuse(script string) error {
+	h.tb.Helper()
+	steps := steps(script)
+	if len(steps) == 0 {
+		h.tb.Fatal("nothing to refuse")
+	}
+	for _, step := range steps[:len(steps)-1] {
+		h.Play(step)
+	}
+	err := Play(h.Game, h.Dice, steps[len(steps)-1])
+	if err == nil {
+		h.tb.Fatalf("%q was not refused", steps[len(steps)-1])
+	}
+	h.Check()
+	return err
+}
+
+// Check fails the test if the game breaks an invariant
+func (h *Harness) Check() {
+	h.tb.Helper()
+	if err := Check(h.Game); err != nil {
+		h.tb.Fatal(err)
+	}
+}
+
+// Player returns the player with the ID, failing the test if there is none
+func (h *Harness) Player(id string) *game.Player {
+	h.tb.Helper()
+	i := slices.IndexFunc(h.Game.Players(), func(p *game.Player) bool { return p.ID() == id })
+	if i < 0 {
+		h.tb.Fatalf("no player %q", id)
+	}
+	return h.Game.Players()[i]
+}
+
+// ExpectEvents fails the test unless the events since the last call are
+// of the types given
+func (h *Harness) ExpectEvents(want ...game.EventType) {
+	h.tb.Helper()
+	got := h.Events.Types()[h.seen:]
+	h.seen += len(got)
+	if !slices.Equal(want, got) {
+		h.tb.Errorf("events: +want -got\n%s", cmp.Diff(want, got))
+	}
+}
+
+// New returns a harness for a new game, rolling only scripted dice
+func New(tb testing.TB, opts ...game.GameOpt) *Harness {
+	h := &Harness{Dice: NewDice(), Events: &Recorder{}, tb: tb}
+	h.Game = game.NewGame(append(opts, game.WithRandom(h.Dice.Roll), h.Events.Option())...)
+	return h
+}
@@ -0,0 +1,120 @@
+package farkletest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func TestHarness(t *testing.T) {
+	t.Parallel()
+	h := farkletest.New(t, game.WithTarget(500))
+	h.Start("ann", "bob")
+	h.ExpectEvents(game.EventJoined, game.EventJoined, game.EventStarted)
+	h.Play("roll 1 1 1 5 4 2; keep 0 1 2 3; bank")
+	h.ExpectEvents(game.EventRolled, game.EventKept, game.EventBanked)
+	h.Play(`
+		accept        # 350 with two dice
+		roll 5 3
+		keep 0
+		bank
+	`)
+	h.ExpectEvents(game.EventAccepted, game.EventRolled, game.EventKept, game.EventBanked)
+	h.Play("reject; roll 2 3 4 6 2 3")
+	h.ExpectEvents(game.EventRejected, game.EventRolled, game.EventFarkle)
+	h.Play("reject; roll 5 5 5 2 3 4; keep 0 1 2; bank; reject; roll 2 3 4 6 2 3")
+	h.ExpectEvents(game.EventRejected, game.EventRolled, game.EventKept, game.EventBanked, game.EventRejected, game.EventRolled, game.EventFarkle, game.EventEnded)
+	if got := h.Player("ann").Score(); got != 350 {
+		t.Errorf("ann: +want -got\n\t+350\n\t-%d", got)
+	}
+	if got := h.Game.Winner().ID(); got != "bob" {
+		t.Errorf("winner: +want -got\n\t+bob\n\t-%s", got)
+	}
+}
+
+func TestHarness_Refuse(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		script string
+		want   error
+	}
+	for _, c := range []testCase{
+		{name: "bank before rolling", script: "bank", want: game.ErrMustRollFirst},
+		{name: "keep a non-scoring die", script: "roll 1 1 1 5 4 2; keep 4", want: game.ErrInvalidKeep},
+		{name: "too few dice", script: "roll 1 2", want: farkletest.ErrScript},
+		{name: "invalid die", script: "roll 7 1 1 1 1 1", want: farkletest.ErrScript},
+		{name: "unknown action", script: "dance", want: farkletest.ErrScript},
+		{name: "argument to bank", script: "bank 3", want: farkletest.ErrScript},
+		{name: "keep nothing", script: "roll 1 1 1 5 4 2; keep", want: farkletest.ErrScript},
+		{name: "not a number", script: "roll 1 1 1 5 4 2; keep one", want: farkletest.ErrScript},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			h := farkletest.New(t)
+			h.Start("ann", "bob")
+			if err := h.Refuse(c.script); !errors.Is(err, c.want) {
+				t.Errorf("error: +want -got\n\t+%v\n\t-%v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(game.NewSeededRandom(7)), game.WithTarget(2000))
+	for _, name := range []string{"ann", "bob", "cat"} {
+		g.Join(name)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	bot := strategy.Threshold("bot", 300, 3)
+	for turns := 0; !g.Over(); turns++ {
+		if turns > 1000 {
+			t.Fatal("game did not finish")
+		}
+		if err := strategy.TakeTurn(g, bot); err != nil {
+			t.Fatal(err)
+		}
+		if err := farkletest.Check(g); err != nil {
+			t.Fatalf("turn %d: %v", turns, err)
+		}
+	}
+}
+
+func TestDice(t *testing.T) {
+	t.Parallel()
+	dice := farkletest.NewDice(3, 4)
+	dice.Push(5)
+	for _, want := range []uint8{3, 4, 5} {
+		if got := dice.Roll(); got != want {
+			t.Errorf("roll: +want -got\n\t+%d\n\t-%d", want, got)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("rolled with no dice left")
+		}
+	}()
+	dice.Roll()
+}
+
+func TestCycle(t *testing.T) {
+	t.Parallel()
+	roll := farkletest.Cycle(1, 2)
+	for _, want := range []uint8{1, 2, 1} {
+		if got := roll(); got != want {
+			t.Errorf("roll: +want -got\n\t+%d\n\t-%d", want, got)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("cycled through no dice")
+		}
+	}()
+	farkletest.Cycle()
+}
@@ -0,0 +1,123 @@
+package farkletest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+var ErrScript = errors.New("invalid script")
+
+// Play performs the actions of a script on the game, for whoever's turn
+// it is. Actions are separated by semicolons or new lines, and anything
+// after a # is a comment. They are
+//
+//	accept
+//	reject
+//	roll [dice...]
+//	keep <index...>
+//	bank
+//	undo
+//	skip
+//
+// A roll with dice pushes them onto the scripted dice first, and must use
+// exactly those dice. The game must roll from the scripted dice.
+func Play(g *game.Game, dice *Dice, script string) error {
+	for n, step := range steps(script) {
+		if err := play(g, dice, step); err != nil {
+			return fmt.Errorf("step %d %q: %w", n+1, step, err)
+		}
+	}
+	return nil
+}
+
+// steps splits a script into its actions, dropping comments and blank lines
+func steps(script string) []string {
+	var ret []string
+	for _, line := range strings.Split(script, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		for _, step := range strings.Split(line, ";") {
+			if step = strings.Join(strings.Fields(step), " "); step != "" {
+				ret = append(ret, step)
+			}
+		}
+	}
+	return ret
+}
+
+func play(g *game.Game, dice *Dice, step string) error {
+	fields := strings.Fields(step)
+	args, err := numbers(fields[1:])
+	if err != nil {
+		return err
+	}
+	if fields[0] != "roll" && fields[0] != "keep" && len(args) > 0 {
+		return fmt.Errorf("%w: %s takes no arguments", ErrScript, fields[0])
+	}
+	switch fields[0] {
+	case "accept":
+		return g.Accept()
+	case "reject":
+		return g.Reject()
+	case "bank":
+		return g.Bank()
+	case "undo":
+		return g.Undo()
+	case "skip":
+		return g.Skip()
+	case "keep":
+		if len(args) == 0 {
+			return fmt.Errorf("%w: keep needs the indexes of the dice", ErrScript)
+		}
+		return g.Keep(args...)
+	case "roll":
+		return roll(g, dice, args)
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrScript, fields[0])
+	}
+}
+
+// roll rolls the game's dice, first pushing any given in the script
+func roll(g *game.Game, dice *Dice, args []int) error {
+	if len(args) == 0 || len(g.Players()) == 0 || g.State() != game.AwaitingRoll {
+		return g.Roll()
+	}
+	turn := g.Current().Current()
+	want := make(game.Roll, len(args))
+	for i, d := range args {
+		if d < 1 || d > 6 {
+			return fmt.Errorf("%w: invalid die %d", ErrScript, d)
+		}
+		want[i] = uint8(d)
+	}
+	switch {
+	case len(want) != turn.Available():
+		return fmt.Errorf("%w: rolling %d dice, script gives %d", ErrScript, turn.Available(), len(want))
+	case dice.Len() > 0:
+		return fmt.Errorf("%w: %d dice already queued", ErrScript, dice.Len())
+	}
+	dice.Push(want...)
+	if err := g.Roll(); err != nil {
+		return err
+	}
+	if got := turn.Dice(); !slices.Equal(got, want) {
+		return fmt.Errorf("%w: rolled %v, script gives %v", ErrScript, got, want)
+	}
+	return nil
+}
+
+func numbers(fields []string) ([]int, error) {
+	ret := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrScript, field)
+		}
+		ret[i] = n
+	}
+	return ret, nil
+}
@@ -0,0 +1,30 @@
+package game
+
+import "errors"
+
+var (
+	// ErrNotYourTurn is returned when a player acts out of turn: by a
+	// Player's actions, and those of their turns, when another player is
+	// to play in their game or they have no turn in progress, and by
+	// Game.ApplyEvent, and so Replay, for another player's event. The
+	// Apply function and the Game's own actions always act for the
+	// current player, so they never return it.
+	ErrNotYourTurn        = errors.New("not your turn")
+	ErrMustDecide         = errors.New("must accept or reject the previous turn first")
+	ErrTurnInProgress     = errors.New("turn already in progress")
+	ErrNothingToAccept    = errors.New("no dice to accept")
+	ErrMustRollFirst      = errors.New("must roll first")
+	ErrMustKeepBeforeRoll = errors.New("must keep at least one scoring die before rolling again")
+	ErrMustKeepBeforeBank = errors.New("must keep at least one scoring die before banking")
+	ErrInvalidKeep        = errors.New("invalid keep")
+	ErrTurnOver           = errors.New("turn is over")
+	ErrNothingToUndo      = errors.New("nothing to undo")
+	ErrUndoDisabled       = errors.New("undo is disabled for this game")
+	ErrNoPlayers          = errors.New("no players have joined")
+	ErrNotStarted         = errors.New("game has not started")
+	ErrGameOver           = errors.New("game is over")
+	ErrBelowOpening       = errors.New("turn is below the opening score")
+	ErrUnevenTeams        = errors.New("teams must be of equal size, with at least two teams")
+	ErrInvalidRoll        = errors.New("invalid roll")
+	ErrUnknownAction      = errors.New("unknown action")
+)
@@ -0,0 +1,59 @@
+package game
+
+// EventType names something that happened in a game
+type EventType string
+
+const (
+	EventJoined   EventType = "joined"
+	EventStarted  EventType = "started"
+	EventAccepted EventType = "accepted"
+	EventRejected EventType = "rejected"
+	EventRolled   EventType = "rolled"
+	EventKept     EventType = "kept"
+	EventBanked   EventType = "banked"
+	EventFarkle   EventType = "farkle"
+	EventUndone   EventType = "undone"
+	EventSkipped  EventType = "skipped"
+	EventEnded    EventType = "ended"
+)
+
+// Event records a single action in a game. Together a game's events hold
+// everything needed to replay it.
+type Event struct {
+	Type EventType `json:"type"`
+	// Player is the ID of the player who acted
+	Player string `json:"player,omitempty"`
+	// Name is the name a player joined with
+	Name string `json:"name,omitempty"`
+	// Team is the team a player joined
+	Team string `json:"team,omitempty"`
+	// Handicap is the advantage a player joined with
+	Handicap *Handicap `json:"handicap,omitempty"`
+	// Dice is the roll thrown
+	Dice Roll `json:"dice,omitempty"`
+	// Keep is the indexes of the dice kept
+	Keep []int `json:"keep,omitempty"`
+	// Score is the score of the turn after the action, or the target
+	// score when the game starts
+	Score uint32 `json:"score,omitempty"`
+	// Available is the number of dice left to roll after the action
+	Available int `json:"available,omitempty"`
+	// Opening is the score needed to get on the board, when the game starts
+	Opening uint32 `json:"opening,omitempty"`
+	// NoUndo is whether keeps and banks can't be taken back, when the game
+	// starts
+	NoUndo bool `json:"noUndo,omitempty"`
+}
+
+// WithListener calls listener with every event in the game as it happens
+func WithListener(listener func(Event)) GameOpt {
+	return func(g *Game) {
+		g.listeners = append(g.listeners, listener)
+	}
+}
+
+func (g *Game) emit(event Event) {
+	for _, listener := range g.listeners {
+		listener(event)
+	}
+}
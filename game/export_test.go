@@ -0,0 +1,7 @@
+package game
+
+// Computed works out the scorings of the roll with the scorers rather
+// than looking them up in the table
+func Computed(r Roll) []*Scoring {
+	return r.score()
+}
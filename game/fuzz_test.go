@@ -0,0 +1,249 @@
+package game_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// dice turns arbitrary bytes into a roll of up to six dice
+func dice(b []byte) game.Roll {
+	roll := make(game.Roll, 0, 6)
+	for _, d := range b[:min(len(b), 6)] {
+		roll = append(roll, d%6+1)
+	}
+	return roll
+}
+
+// checkScorings checks the scorings of a roll use each of its dice at most
+// once, score something and come highest first
+func checkScorings(t *testing.T, roll game.Roll, scorings []*game.Scoring) {
+	t.Helper()
+	for i, scoring := range scorings {
+		if scoring.Score == 0 || len(scoring.Set) == 0 {
+			t.Fatalf("%v: empty scoring %+v", roll, scoring)
+		}
+		if i > 0 && scorings[i-1].Score < scoring.Score {
+			t.Fatalf("%v: scoring %d of %d comes after %d", roll, i, scoring.Score, scorings[i-1].Score)
+		}
+		for j, idx := range scoring.Set {
+			if idx < 0 || idx >= len(roll) {
+				t.Fatalf("%v: scoring %+v has no die %d", roll, scoring, idx)
+			}
+			if j > 0 && scoring.Set[j-1] >= idx {
+				t.Fatalf("%v: scoring %+v is not in order or repeats a die", roll, scoring)
+			}
+		}
+		if len(scoring.Set) == 1 && roll[scoring.Set[0]] != 1 && roll[scoring.Set[0]] != 5 {
+			t.Fatalf("%v: single die %d scores %d", roll, roll[scoring.Set[0]], scoring.Score)
+		}
+	}
+}
+
+// turnState is what a turn looked like before an action
+type turnState struct {
+	state     game.State
+	score     uint32
+	available int
+	held      []int
+	rolls     int
+}
+
+func capture(turn *game.Turn) turnState {
+	return turnState{
+		state:     turn.State(),
+		score:     turn.Result(),
+		available: turn.Available(),
+		held:      slices.Clone(turn.Held()),
+		rolls:     len(turn.Rolls()),
+	}
+}
+
+// checkTurn checks the invariants that hold after any action on a turn
+func checkTurn(t *testing.T, turn *game.Turn) {
+	t.Helper()
+	if a := turn.Available(); a < 0 || a > 6 {
+		t.Fatalf("%d dice available", a)
+	}
+	held := turn.Held()
+	for j, idx := range held {
+		if idx < 0 || idx >= len(turn.Dice()) {
+			t.Fatalf("held die %d of roll %v", idx, turn.Dice())
+		}
+		if slices.Contains(held[:j], idx) {
+			t.Fatalf("die %d held twice: %v", idx, held)
+		}
+	}
+}
+
+// checkKeep checks a keep of the dice at indexes from a turn that was in
+// the state before
+func checkKeep(t *testing.T, turn *game.Turn, before turnState, indexes []int, err error) {
+	t.Helper()
+	repeated := false
+	for j, idx := range indexes {
+		repeated = repeated || slices.Contains(indexes[:j], idx) || slices.Contains(before.held, idx)
+	}
+	if err != nil {
+		if repeated && before.state != game.Farkled && before.state != game.Banked && turn.Dice() != nil && !errors.Is(err, game.ErrInvalidKeep) {
+			t.Fatalf("keep %v with %v held: got %v, want %v", indexes, before.held, err, game.ErrInvalidKeep)
+		}
+		if after := capture(turn); !slices.Equal(after.held, before.held) || after.score != before.score || after.available != before.available || after.rolls != before.rolls {
+			t.Fatalf("refused keep %v changed the turn from %+v to %+v", indexes, before, after)
+		}
+		return
+	}
+	if repeated {
+		t.Fatalf("kept %v with %v held", indexes, before.held)
+	}
+	if turn.Result() <= before.score {
+		t.Fatalf("keep %v took the score from %d to %d", indexes, before.score, turn.Result())
+	}
+	var got, want game.Roll
+	for _, roll := range turn.Rolls()[before.rolls:] {
+		got = append(got, roll...)
+	}
+	for _, idx := range indexes {
+		want = append(want, turn.Dice()[idx])
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if len(want) == 0 || !slices.Equal(got, want) {
+		t.Fatalf("keep %v of %v scored dice %v", indexes, turn.Dice(), got)
+	}
+}
+
+func FuzzRoll_Score(f *testing.F) {
+	for _, seed := range [][]byte{{0, 0, 0, 4, 3, 1}, {0, 1, 2, 3, 4, 5}, {1, 1, 2, 2, 3, 3}, {0, 0, 0, 2, 2, 2}, {4}} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		roll := dice(b)
+		before := slices.Clone(roll)
+		checkScorings(t, roll, roll.Score())
+		if !slices.Equal(roll, before) {
+			t.Fatalf("scoring changed the roll from %v to %v", before, roll)
+		}
+	})
+}
+
+// FuzzTurn_Keep plays a turn with the dice and script given. Each byte of
+// the script is an action chosen by its top two bits: a roll, a keep of the
+// dice in the mask of the low six bits, a keep of the two dice numbered by
+// the low three bits and the three above them, or an undo.
+func FuzzTurn_Keep(f *testing.F) {
+	f.Add([]byte{0, 0, 0, 4, 3, 1}, []byte{0x00, 0x4f, 0x00})
+	f.Add([]byte{0, 4, 0, 4, 1, 2}, []byte{0x00, 0x88, 0x90, 0x41, 0xc0, 0x48})
+	f.Add([]byte{4, 4, 4, 4, 4, 4}, []byte{0x00, 0x7f, 0x00, 0x43, 0x48})
+	f.Add([]byte{1, 2, 3}, []byte{0x00, 0x41, 0x00})
+	f.Fuzz(func(t *testing.T, dice []byte, script []byte) {
+		// the dice, given as arbitrary bytes, are rolled over and over
+		faces := []uint8{1}
+		if len(dice) > 0 {
+			faces = make([]uint8, len(dice))
+			for i, b := range dice {
+				faces[i] = b%6 + 1
+			}
+		}
+		turn := game.NewTurn(farkletest.Cycle(faces...))
+		for _, b := range script {
+			before := capture(turn)
+			switch b >> 6 {
+			case 0:
+				err := turn.Roll()
+				switch {
+				case err != nil:
+				case turn.Farkle():
+					if turn.Result() != 0 || turn.Available() != 0 {
+						t.Fatalf("farkle left %d points and %d dice", turn.Result(), turn.Available())
+					}
+				case turn.Result() != before.score:
+					t.Fatalf("roll took the score from %d to %d", before.score, turn.Result())
+				}
+			case 1, 2:
+				var indexes []int
+				if b>>6 == 1 {
+					for idx := range 6 {
+						if b&(1<<idx) != 0 {
+							indexes = append(indexes, idx)
+						}
+					}
+				} else {
+					indexes = []int{int(b & 7), int(b >> 3 & 7)}
+				}
+				arg := slices.Clone(indexes)
+				err := turn.Keep(arg...)
+				if !slices.Equal(arg, indexes) {
+					t.Fatalf("keep changed its argument from %v to %v", indexes, arg)
+				}
+				checkKeep(t, turn, before, indexes, err)
+			case 3:
+				if err := turn.Undo(); err == nil && turn.Result() > before.score {
+					t.Fatalf("undo took the score from %d to %d", before.score, turn.Result())
+				}
+			}
+			checkTurn(t, turn)
+		}
+	})
+}
+
+// TestRoll_ScoreProperties checks the scorings of every roll of one to six
+// dice
+func TestRoll_ScoreProperties(t *testing.T) {
+	t.Parallel()
+	for n := 1; n <= 6; n++ {
+		every(n, func(roll game.Roll) {
+			checkScorings(t, roll, roll.Score())
+		})
+	}
+}
+
+// TestTurn_KeepProperties keeps each scoring of every roll of six dice, then
+// every other die
+func TestTurn_KeepProperties(t *testing.T) {
+	t.Parallel()
+	every(6, func(roll game.Roll) {
+		for _, scoring := range roll.Score() {
+			turn := game.NewTurn(farkletest.NewDice(roll...).Roll)
+			if err := turn.Roll(); err != nil {
+				t.Fatal(err)
+			}
+			before := capture(turn)
+			err := turn.Keep(scoring.Set...)
+			checkKeep(t, turn, before, scoring.Set, err)
+			if err != nil {
+				t.Fatalf("%v: keep %v: %v", roll, scoring.Set, err)
+			}
+			if got := turn.Result(); got != scoring.Score {
+				t.Fatalf("%v: keep %v scored %d, want %d", roll, scoring.Set, got, scoring.Score)
+			}
+			checkTurn(t, turn)
+			for idx := range roll {
+				before := capture(turn)
+				err := turn.Keep(idx)
+				checkKeep(t, turn, before, []int{idx}, err)
+				checkTurn(t, turn)
+			}
+		}
+	})
+}
+
+// every calls yield with every roll of n dice
+func every(n int, yield func(game.Roll)) {
+	roll := make(game.Roll, n)
+	var visit func(i int)
+	visit = func(i int) {
+		if i == n {
+			yield(slices.Clone(roll))
+			return
+		}
+		for d := uint8(1); d <= 6; d++ {
+			roll[i] = d
+			visit(i + 1)
+		}
+	}
+	visit(0)
+}
@@ -1,18 +1,311 @@
 package game
 
+import (
+	"log/slog"
+	"slices"
+)
+
+const (
+	defaultTarget = 10_000
+)
+
+type GameOpt func(*Game)
+
+// WithoutUndo disables taking back keeps and banks, for competitive play
+func WithoutUndo() GameOpt {
+	return func(g *Game) {
+		g.position.noUndo = true
+	}
+}
+
+// WithRandom sets the dice used by every player in the game
+func WithRandom(random Random) GameOpt {
+	return func(g *Game) {
+		g.random = random
+	}
+}
+
+// WithTarget sets the score that triggers the final round
+func WithTarget(score uint32) GameOpt {
+	return func(g *Game) {
+		g.position.target = score
+	}
+}
+
+// WithGameID sets the identity of the game, which is otherwise generated
+func WithGameID(id string) GameOpt {
+	return func(g *Game) {
+		g.id = id
+	}
+}
+
+// Game plays a game between players. Each action replaces its position,
+// an immutable Position, and brings the players it changed up to date.
 type Game struct {
-	players []*Player
-	currentPlayer int
+	id        string
+	position  Position
+	players   []*Player
+	random    Random
+	listeners []func(Event)
+	logger    *slog.Logger
 }
 
+func (g *Game) ID() string {
+	return g.id
+}
+
+// Next moves play to the next player, offering them the remaining
+// dice and score from the turn that just finished. Once a player
+// reaches the target every other player has one more turn.
 func (g *Game) Next(dice int, score uint32) {
-	// TODO: fill this in to move to the next player
+	before := g.position
+	g.position = g.position.next(dice, score)
+	g.advanced(before)
+}
+
+// advanced logs the start of the final round when moving on from before
+// began it
+func (g *Game) advanced(before Position) {
+	if !before.finalRound && g.position.finalRound {
+		g.logger.Info("final round", "player", g.players[g.position.last].ID(), "score", g.position.Standing(g.position.last))
+	}
+}
+
+func (g *Game) Join(player string, opts ...PlayerOpt) {
+	if g.random == nil {
+		g.random = NewRandom()
+	}
+	if g.id == "" {
+		g.id = newID()
+	}
+	joined := NewPlayer(player, g.random, g.Next, append([]PlayerOpt{WithPlayerLogger(g.logger)}, opts...)...)
+	joined.game = g
+	g.players = append(g.players, joined)
+	g.position.players = slices.Clip(append(g.position.players, joined.state))
+	event := Event{Type: EventJoined, Player: joined.ID(), Name: joined.Name(), Team: joined.Team()}
+	if handicap := joined.Handicap(); handicap != (Handicap{}) {
+		event.Handicap = &handicap
+	}
+	g.emit(event)
+}
+
+// Start begins the first player's turn. In a team game the players are
+// first seated so that turns alternate between teams.
+func (g *Game) Start() error {
+	next, order, err := g.position.start()
+	if err != nil {
+		return g.refused("start", err)
+	}
+	if order != nil {
+		players := make([]*Player, len(order))
+		for i, j := range order {
+			players[i] = g.players[j]
+		}
+		g.players = players
+	}
+	g.position = next
+	g.players[0].set(next.players[0])
+	g.players[0].started()
+	g.logger.Info("started", "players", len(g.players), "target", g.Target(), "opening", g.position.opening)
+	g.emit(Event{Type: EventStarted, Score: g.Target(), Opening: g.position.opening, NoUndo: g.position.noUndo})
+	return nil
+}
+
+// State returns the stage the game has reached
+func (g *Game) State() State {
+	return g.position.State()
+}
+
+// Position returns the game as it is now. It stays as it is however the
+// game goes on, so it can be handed to other goroutines to explore from.
+func (g *Game) Position() Position {
+	return g.position
+}
+
+// Target returns the score that triggers the final round
+func (g *Game) Target() uint32 {
+	return g.position.Target()
+}
+
+// Over reports whether every player has had their final turn
+func (g *Game) Over() bool {
+	return g.position.over
+}
+
+// FinalRound returns the seat of the player who reached the target, when
+// they have, ending the game when play comes back round to them
+func (g *Game) FinalRound() (int, bool) {
+	return g.position.FinalRound()
+}
+
+// Winner returns the player furthest past their target, which without
+// target handicaps is the highest standing, the earliest in turn order
+// winning a tie. In a team game every member of their team wins.
+func (g *Game) Winner() *Player {
+	var winner *Player
+	for _, player := range g.players {
+		if winner == nil || g.Compare(player, winner) > 0 {
+			winner = player
+		}
+	}
+	return winner
+}
+
+// Offer returns the dice and score left by the previous player
+func (g *Game) Offer() (int, uint32) {
+	return g.position.Offer()
+}
+
+// Players returns the players in turn order
+func (g *Game) Players() []*Player {
+	return g.players
+}
+
+// Current returns the player whose turn it is
+func (g *Game) Current() *Player {
+	return g.players[g.position.current]
+}
+
+// Accept starts the current player's turn with the dice and score
+// left by the previous player
+func (g *Game) Accept() error {
+	before, err := g.apply("accept", Action{Type: EventAccepted})
+	if err != nil {
+		return err
+	}
+	player := g.Current()
+	player.started()
+	g.emit(Event{Type: EventAccepted, Player: player.ID(), Score: before.score, Available: before.dice})
+	return nil
+}
+
+// Reject starts the current player's turn with six dice and no score
+func (g *Game) Reject() error {
+	if _, err := g.apply("reject", Action{Type: EventRejected}); err != nil {
+		return err
+	}
+	player := g.Current()
+	player.started()
+	g.emit(Event{Type: EventRejected, Player: player.ID()})
+	return nil
+}
+
+// Roll rolls the current player's available dice
+func (g *Game) Roll() error {
+	if err := g.position.rollable(); err != nil {
+		return g.refused("roll", err)
+	}
+	return g.roll(draw(g.random, g.position.Available()))
+}
+
+// roll throws the dice for the current player
+func (g *Game) roll(dice Roll) error {
+	player := g.Current()
+	turn := player.Current()
+	before, err := g.apply("roll", Action{Type: EventRolled, Dice: dice})
+	if err != nil {
+		return err
+	}
+	turn.rolled(before.Turn())
+	g.advanced(before)
+	g.emit(Event{Type: EventRolled, Player: player.ID(), Dice: turn.Dice()})
+	if turn.Farkle() {
+		g.emit(Event{Type: EventFarkle, Player: player.ID()})
+		g.ended()
+	}
+	return nil
+}
+
+// Keep keeps the given dice for the current player
+func (g *Game) Keep(dice ...int) error {
+	keep := slices.Clone(dice)
+	before, err := g.apply("keep", Action{Type: EventKept, Keep: keep})
+	if err != nil {
+		return err
+	}
+	player := g.Current()
+	turn := player.Current()
+	turn.kept(keep, before.turn())
+	g.emit(Event{Type: EventKept, Player: player.ID(), Keep: keep, Score: turn.Result(), Available: turn.Available()})
+	return nil
+}
+
+// Bank concludes the current player's turn
+func (g *Game) Bank() error {
+	player := g.Current()
+	turn := player.Current()
+	before, err := g.apply("bank", Action{Type: EventBanked})
+	if err != nil {
+		return err
+	}
+	turn.banked()
+	g.advanced(before)
+	g.emit(Event{Type: EventBanked, Player: player.ID(), Score: turn.Result(), Available: turn.Available()})
+	g.ended()
+	return nil
+}
+
+// Skip ends the current player's turn without scoring, abandoning any turn
+// in progress and leaving nothing for the next player to accept. It is for
+// players who are away.
+func (g *Game) Skip() error {
+	before, err := g.apply("skip", Action{Type: EventSkipped})
+	if err != nil {
+		return err
+	}
+	skipped := g.players[before.current]
+	skipped.logger.Info("skipped", "score", before.Turn())
+	g.advanced(before)
+	g.emit(Event{Type: EventSkipped, Player: skipped.ID()})
+	g.ended()
+	return nil
+}
+
+func (g *Game) ended() {
+	if g.position.over {
+		g.logger.Info("game over", "winner", g.Winner().ID(), "score", g.Standing(g.Winner()))
+		g.emit(Event{Type: EventEnded, Player: g.Winner().ID(), Score: g.Standing(g.Winner())})
+	}
+}
+
+// Undo takes back the most recent keep, or the previous player's bank
+// when the current player has not yet rolled. Rolls are never undone, and
+// nor is anything before a skipped turn.
+func (g *Game) Undo() error {
+	before, err := g.apply("undo", Action{Type: EventUndone})
+	if err != nil {
+		return err
+	}
+	player := g.Current()
+	if before.turn().dice != nil {
+		player.Current().undone()
+	}
+	g.emit(Event{Type: EventUndone, Player: player.ID(), Score: g.position.Turn(), Available: g.position.Available()})
+	return nil
 }
 
-func (g *Game) Join(player string) {
-	g.players = append(g.players, NewPlayer(player, NewRandom(), g.Next))
+// apply takes the action, bringing the players in the seats it moved
+// between up to date, and returns the position from before it
+func (g *Game) apply(action string, a Action) (Position, error) {
+	before := g.position
+	next, err := Apply(before, a)
+	if err != nil {
+		return before, g.refused(action, err)
+	}
+	g.position = next
+	g.players[before.current].set(next.players[before.current])
+	g.players[next.current].set(next.players[next.current])
+	return before, nil
 }
 
-func (g *Game) Start() {
-	
+func NewGame(opts ...GameOpt) *Game {
+	game := &Game{logger: nop}
+	for _, opt := range opts {
+		opt(game)
+	}
+	if game.id == "" {
+		game.id = newID()
+	}
+	game.logger = game.logger.With("game", game.id)
+	return game
 }
@@ -0,0 +1,518 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+func TestGame_Undo(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		opts  []game.GameOpt
+		play  func(t *testing.T, g *game.Game)
+		err   bool
+		score uint32
+	}
+	for _, c := range []testCase{
+		{
+			name: "undo keep",
+			play: func(t *testing.T, g *game.Game) {
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					t.Fatal(err)
+				}
+			},
+			score: 0,
+		},
+		{
+			name: "undo second keep only",
+			play: func(t *testing.T, g *game.Game) {
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Keep(0, 1, 2); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Keep(3); err != nil {
+					t.Fatal(err)
+				}
+			},
+			score: 300,
+		},
+		{
+			name: "undo bank before next roll",
+			play: func(t *testing.T, g *game.Game) {
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Bank(); err != nil {
+					t.Fatal(err)
+				}
+			},
+			score: 350,
+		},
+		{
+			name: "roll cannot be undone",
+			play: func(t *testing.T, g *game.Game) {
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+			},
+			err: true,
+		},
+		{
+			name: "undo disabled",
+			opts: []game.GameOpt{game.WithoutUndo()},
+			play: func(t *testing.T, g *game.Game) {
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					t.Fatal(err)
+				}
+			},
+			err:   true,
+			score: 350,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			opts := append([]game.GameOpt{game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll)}, c.opts...)
+			g := game.NewGame(opts...)
+			g.Join("one")
+			g.Join("two")
+			if err := g.Start(); err != nil {
+				t.Fatal(err)
+			}
+			c.play(t, g)
+			err := g.Undo()
+			if !cmp.Equal(c.err, err != nil) {
+				t.Error("unexpected error", err)
+			}
+			if got := g.Current().Turn(); got != c.score {
+				t.Errorf("score: +want -got\n\t+%d\n\t-%d", c.score, got)
+			}
+		})
+	}
+}
+
+func TestGame_Errors(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		play  func(g *game.Game) error
+		want  error
+		state game.State
+	}
+	for _, c := range []testCase{
+		{
+			name:  "keep before roll",
+			play:  func(g *game.Game) error { return g.Keep(0) },
+			want:  game.ErrMustRollFirst,
+			state: game.AwaitingRoll,
+		},
+		{
+			name:  "bank before roll",
+			play:  func(g *game.Game) error { return g.Bank() },
+			want:  game.ErrMustRollFirst,
+			state: game.AwaitingRoll,
+		},
+		{
+			name: "roll twice",
+			play: func(g *game.Game) error {
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				return g.Roll()
+			},
+			want:  game.ErrMustKeepBeforeRoll,
+			state: game.AwaitingKeep,
+		},
+		{
+			name: "keep non-scoring die",
+			play: func(g *game.Game) error {
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				return g.Keep(4)
+			},
+			want:  game.ErrInvalidKeep,
+			state: game.AwaitingKeep,
+		},
+		{
+			name:  "reject during turn",
+			play:  func(g *game.Game) error { return g.Reject() },
+			want:  game.ErrTurnInProgress,
+			state: game.AwaitingRoll,
+		},
+		{
+			name: "roll before deciding",
+			play: func(g *game.Game) error {
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					return err
+				}
+				if err := g.Bank(); err != nil {
+					return err
+				}
+				return g.Roll()
+			},
+			want:  game.ErrMustDecide,
+			state: game.AwaitingDecision,
+		},
+		{
+			name: "accept farkle",
+			play: func(g *game.Game) error {
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					return err
+				}
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				return g.Accept()
+			},
+			want:  game.ErrNothingToAccept,
+			state: game.AwaitingDecision,
+		},
+		{
+			name: "game over",
+			play: func(g *game.Game) error {
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				if err := g.Keep(0, 1, 2, 3); err != nil {
+					return err
+				}
+				if err := g.Bank(); err != nil {
+					return err
+				}
+				if err := g.Reject(); err != nil {
+					return err
+				}
+				if err := g.Roll(); err != nil {
+					return err
+				}
+				return g.Reject()
+			},
+			want:  game.ErrGameOver,
+			state: game.GameOver,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6, 4, 3).Roll), game.WithTarget(300))
+			g.Join("one")
+			g.Join("two")
+			if err := g.Start(); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.play(g); !errors.Is(err, c.want) {
+				t.Errorf("error: +want -got\n\t+%v\n\t-%v", c.want, err)
+			}
+			if got := g.State(); got != c.state {
+				t.Errorf("state: +want -got\n\t+%v\n\t-%v", c.state, got)
+			}
+		})
+	}
+}
+
+func TestGame_Teams(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6, 1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6).Roll), game.WithTarget(300))
+	g.Join("ann", game.WithTeam("red"))
+	g.Join("amy", game.WithTeam("red"))
+	g.Join("bob", game.WithTeam("blue"))
+	g.Join("ben", game.WithTeam("blue"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, player := range g.Players() {
+		order = append(order, player.Name())
+	}
+	if diff := cmp.Diff([]string{"ann", "bob", "amy", "ben"}, order); diff != "" {
+		t.Errorf("turn order: +want -got\n%s", diff)
+	}
+	for turn := range 4 {
+		if turn > 0 {
+			if err := g.Reject(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := g.Roll(); err != nil {
+			t.Fatal(err)
+		}
+		if turn%2 == 0 {
+			if err := g.Keep(0, 1, 2, 3); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Bank(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if !g.Over() {
+		t.Fatal("game should be over once play returns to the team that reached the target")
+	}
+	teams := g.Teams()
+	if teams[0].Name != "red" || teams[0].Score() != 700 || teams[1].Score() != 0 {
+		t.Errorf("unexpected teams %+v", teams)
+	}
+	if got := g.Standing(g.Players()[2]); got != 700 {
+		t.Errorf("standing: +want -got\n\t+%d\n\t-%d", 700, got)
+	}
+	if got := g.Winner().Team(); got != "red" {
+		t.Errorf("winning team: +want -got\n\t+%s\n\t-%s", "red", got)
+	}
+}
+
+func TestGame_UnevenTeams(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame()
+	g.Join("ann", game.WithTeam("red"))
+	g.Join("amy", game.WithTeam("red"))
+	g.Join("bob", game.WithTeam("blue"))
+	if err := g.Start(); !errors.Is(err, game.ErrUnevenTeams) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrUnevenTeams, err)
+	}
+}
+
+func TestGame_Handicap(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 1, 1, 1, 5, 4, 2, 5, 5, 5).Roll), game.WithTarget(1_000), game.WithOpening(500))
+	g.Join("one", game.WithHandicap(game.Handicap{Start: 200, Opening: 200, Target: 700}))
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for _, play := range []func() error{
+		g.Roll, func() error { return g.Keep(0, 1, 2, 3) }, g.Bank,
+		g.Reject, g.Roll, func() error { return g.Keep(0, 1, 2) },
+	} {
+		if err := play(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Bank(); !errors.Is(err, game.ErrBelowOpening) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrBelowOpening, err)
+	}
+	for _, play := range []func() error{g.Roll, func() error { return g.Keep(0, 1, 2) }, g.Bank} {
+		if err := play(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !g.Over() {
+		t.Fatal("game should be over once the handicapped player reached their target")
+	}
+	var got []string
+	for _, standing := range g.Standings() {
+		got = append(got, fmt.Sprintf("%s %d/%d %+v", standing.Player.Name(), standing.Score, standing.Target, standing.Handicap))
+	}
+	want := []string{"one 550/300 {Start:200 Opening:200 Target:700}", "two 800/1000 {Start:0 Opening:0 Target:0}"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("standings: +want -got\n%s", diff)
+	}
+}
+
+func TestGame_Logging(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	g := game.NewGame(game.WithGameID("g1"), game.WithLogger(logger), game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll))
+	g.Join("one", game.WithID("a"))
+	g.Join("two", game.WithID("b"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Keep(4); err == nil {
+		t.Fatal("kept a non-scoring die")
+	}
+	if err := g.Keep(0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Bank(); err != nil {
+		t.Fatal(err)
+	}
+	type record struct {
+		Msg      string
+		Game     string
+		Player   string
+		Scorings []game.Roll
+	}
+	var got []record
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var r record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, r)
+	}
+	want := []record{
+		{Msg: "turn started", Game: "g1", Player: "a"},
+		{Msg: "started", Game: "g1"},
+		{Msg: "rolled", Game: "g1", Player: "a"},
+		{Msg: "action refused", Game: "g1", Player: "a"},
+		{Msg: "kept", Game: "g1", Player: "a", Scorings: []game.Roll{{5}, {1, 1, 1}}},
+		{Msg: "banked", Game: "g1", Player: "a"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("records: +want -got\n%s", diff)
+	}
+}
+
+func TestGame_NilLoggers(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithLogger(nil), game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll))
+	g.Join("one", game.WithPlayerLogger(nil))
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Keep(4); err == nil {
+		t.Fatal("kept a non-scoring die")
+	}
+	if err := g.Keep(0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Bank(); err != nil {
+		t.Fatal(err)
+	}
+	turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll, game.WithTurnLogger(nil))
+	if err := turn.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := turn.Keep(0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := turn.Bank(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGame_Skip(t *testing.T) {
+	t.Parallel()
+	var events []game.Event
+	g := game.NewGame(game.WithGameID("g1"), game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll), game.WithListener(func(e game.Event) { events = append(events, e) }))
+	g.Join("one", game.WithID("a"))
+	g.Join("two", game.WithID("b"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Current().ID(); got != "b" {
+		t.Errorf("current: +want -got\n\t+b\n\t-%s", got)
+	}
+	if dice, score := g.Offer(); dice != 0 || score != 0 {
+		t.Errorf("skipped turn left %d dice for %d", dice, score)
+	}
+	if got := events[len(events)-1]; got.Type != game.EventSkipped || got.Player != "a" {
+		t.Errorf("event: got %+v, want skipped by a", got)
+	}
+	replayed, err := game.Replay(events, game.WithGameID("g1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(g.Snapshot(), replayed.Snapshot()); diff != "" {
+		t.Errorf("replayed: +want -got\n%s", diff)
+	}
+}
+
+func TestGame_SkipUndo(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 5, 5, 5, 2, 3, 4).Roll))
+	g.Join("one", game.WithID("a"))
+	g.Join("two", game.WithID("b"))
+	if err := g.Skip(); !errors.Is(err, game.ErrNotStarted) {
+		t.Errorf("skip before start: +want -got\n\t+%v\n\t-%v", game.ErrNotStarted, err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for _, play := range []func() error{
+		g.Roll, func() error { return g.Keep(0, 1, 2, 3) }, g.Bank,
+		g.Reject, g.Roll, func() error { return g.Keep(0, 1, 2) }, g.Bank,
+		g.Skip,
+	} {
+		if err := play(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Undo(); !errors.Is(err, game.ErrNothingToUndo) {
+		t.Errorf("undo after skip: +want -got\n\t+%v\n\t-%v", game.ErrNothingToUndo, err)
+	}
+	if got := g.Players()[0].Score(); got != 350 {
+		t.Errorf("score: +want -got\n\t+350\n\t-%d", got)
+	}
+	if got := g.Current().ID(); got != "b" {
+		t.Errorf("current: +want -got\n\t+b\n\t-%s", got)
+	}
+}
+
+func TestGame_PlayerActions(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 5, 3).Roll))
+	g.Join("one")
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	one, two := g.Players()[0], g.Players()[1]
+	for _, step := range []func() error{
+		one.Roll,
+		func() error { return one.Current().Keep(0, 1, 2, 3) },
+		one.Bank,
+		func() error { two.Accept(2, 350); return nil },
+		two.Roll,
+		func() error { return two.Keep(0) },
+	} {
+		if err := step(); err != nil {
+			t.Fatal(err)
+		}
+		if err := farkletest.Check(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := g.Position().Score(0); got != 350 {
+		t.Errorf("score: +want -got\n\t+350\n\t-%d", got)
+	}
+	if got := g.Position().Turn(); got != 400 {
+		t.Errorf("turn: +want -got\n\t+400\n\t-%d", got)
+	}
+	if err := one.Roll(); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrNotYourTurn, err)
+	}
+	if err := one.Turns()[0].Bank(); !errors.Is(err, game.ErrTurnOver) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrTurnOver, err)
+	}
+}
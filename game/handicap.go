@@ -0,0 +1,92 @@
+package game
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Handicap evens out a game between players of different skill. Each
+// field is an advantage given to the player, and the zero value is none.
+type Handicap struct {
+	// Start is a score the player begins the game with
+	Start uint32 `json:"start,omitempty"`
+	// Opening lowers the score the player must bank in one turn to get on
+	// the board
+	Opening uint32 `json:"opening,omitempty"`
+	// Target lowers the score the player must reach to begin the final round
+	Target uint32 `json:"target,omitempty"`
+}
+
+// Standing is a player's place at the end of a game
+type Standing struct {
+	Player *Player
+	// Score is the player's standing, their team's total in a team game
+	Score uint32
+	// Target is the score the player needed to reach
+	Target   uint32
+	Handicap Handicap
+}
+
+// WithOpening sets the score a player must bank in one turn before any
+// of their turns count, which is otherwise zero
+func WithOpening(score uint32) GameOpt {
+	return func(g *Game) {
+		g.position.opening = score
+	}
+}
+
+// Opening returns the score a player must bank in one turn to get on the
+// board
+func (g *Game) Opening() uint32 {
+	return g.position.opening
+}
+
+// OpeningFor returns the score the player must bank in one turn to get on
+// the board, after their handicap, or zero once they are on it
+func (g *Game) OpeningFor(player *Player) uint32 {
+	return g.position.openingFor(player.state)
+}
+
+func (p Position) openingFor(player playerState) uint32 {
+	for _, turn := range player.turns {
+		if turn.banked {
+			return 0
+		}
+	}
+	return p.opening - min(player.handicap.Opening, p.opening)
+}
+
+// TargetFor returns the score that triggers the final round when the
+// player reaches it, after their handicap
+func (g *Game) TargetFor(player *Player) uint32 {
+	return g.position.targetFor(player.state)
+}
+
+func (p Position) targetFor(player playerState) uint32 {
+	return p.Target() - min(player.handicap.Target, p.Target())
+}
+
+// Compare ranks two players by how far their standing is past their
+// target, returning a positive number when a is ahead, negative when b is
+// and zero when they are level. Without target handicaps this is the
+// order of their standings.
+func (g *Game) Compare(a, b *Player) int {
+	return g.position.compare(a.state, b.state)
+}
+
+func (p Position) compare(a, b playerState) int {
+	return cmp.Compare(int64(p.standing(a))-int64(p.targetFor(a)), int64(p.standing(b))-int64(p.targetFor(b)))
+}
+
+// Standings returns every player from first to last, along with their
+// handicap. Level players are ordered by turn order.
+func (g *Game) Standings() []Standing {
+	ret := make([]Standing, 0, len(g.players))
+	for _, player := range g.players {
+		ret = append(ret, Standing{Player: player, Score: g.Standing(player), Target: g.TargetFor(player), Handicap: player.state.handicap})
+	}
+	slices.SortStableFunc(ret, func(a, b Standing) int {
+		return g.Compare(b.Player, a.Player)
+	})
+	return ret
+}
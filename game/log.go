@@ -0,0 +1,61 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger records the game's actions and refused actions to logger,
+// each record carrying the game's ID and, for players, their ID. A nil
+// logger records nothing.
+func WithLogger(logger *slog.Logger) GameOpt {
+	return func(g *Game) {
+		g.logger = orNop(logger)
+	}
+}
+
+// WithPlayerLogger records the player's turns to logger, or nothing when
+// it is nil
+func WithPlayerLogger(logger *slog.Logger) PlayerOpt {
+	return func(p *Player) {
+		p.logger = orNop(logger)
+	}
+}
+
+// WithTurnLogger records the turn's rolls, keeps, banks and farkles to
+// logger, or nothing when it is nil
+func WithTurnLogger(logger *slog.Logger) Opt {
+	return func(t *Turn) {
+		t.logger = orNop(logger)
+	}
+}
+
+// discard is a handler for the logger used when none is given
+type discard struct{}
+
+func (discard) Enabled(context.Context, slog.Level) bool  { return false }
+func (discard) Handle(context.Context, slog.Record) error { return nil }
+func (d discard) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discard) WithGroup(string) slog.Handler           { return d }
+
+var nop = slog.New(discard{})
+
+func orNop(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return nop
+	}
+	return logger
+}
+
+// refused logs an action the current player was not allowed to take
+func (g *Game) refused(action string, err error) error {
+	if err == nil {
+		return nil
+	}
+	logger := g.logger
+	if len(g.players) > 0 {
+		logger = g.Current().logger
+	}
+	logger.Warn("action refused", "action", action, "err", err)
+	return err
+}
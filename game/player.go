@@ -1,60 +1,365 @@
 package game
 
-import "fmt"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+)
 
+type PlayerOpt func(*Player)
+
+// WithID sets a stable identity for the player, so their history can be
+// followed across games regardless of the name they play under
+func WithID(id string) PlayerOpt {
+	return func(p *Player) {
+		p.state.id = id
+	}
+}
+
+// WithTeam puts the player in a team, whose members share a total score
+func WithTeam(team string) PlayerOpt {
+	return func(p *Player) {
+		p.state.team = team
+	}
+}
+
+// WithHandicap gives the player a head start over stronger players
+func WithHandicap(handicap Handicap) PlayerOpt {
+	return func(p *Player) {
+		p.state.handicap = handicap
+	}
+}
+
+// Player plays turns with their own dice, each action replacing their
+// state. The players of a game act through the game, which keeps their
+// state in its own.
 type Player struct {
-	name    string
+	state   playerState
 	random  func() uint8
 	turns   []*Turn
 	current *Turn
 	next    func(dice int, score uint32)
+	logger  *slog.Logger
+	// game is the game the player joined, which takes their actions
+	game *Game
+}
+
+// playerState is a player at one point, as a value
+type playerState struct {
+	id       string
+	name     string
+	team     string
+	handicap Handicap
+	turns    []turn
+	current  turn
+	active   bool
 }
 
+func (p *Player) ID() string {
+	return p.state.id
+}
+
+func (p *Player) Name() string {
+	return p.state.name
+}
+
+// Team returns the name of the player's team, empty when not in a team
+func (p *Player) Team() string {
+	return p.state.team
+}
+
+// Turns returns the player's completed turns
+func (p *Player) Turns() []*Turn {
+	return p.turns
+}
+
+// Handicap returns the advantages the player was given on joining
+func (p *Player) Handicap() Handicap {
+	return p.state.handicap
+}
+
+// Score returns the player's total, including any starting score from
+// their handicap
 func (p *Player) Score() uint32 {
-	var sum uint32
+	return p.state.score()
+}
+
+func (p playerState) score() uint32 {
+	sum := p.handicap.Start
 	for _, turn := range p.turns {
-		sum += turn.Result()
+		sum += turn.score
 	}
 	return sum
 }
 
+// Current returns the turn in progress, or nil between turns
+func (p *Player) Current() *Turn {
+	return p.current
+}
+
+// Active reports whether the player has a turn in progress
+func (p *Player) Active() bool {
+	return p.state.active
+}
+
+// Turn returns the score of the current turn so far
+func (p *Player) Turn() uint32 {
+	if !p.state.active {
+		return 0
+	}
+	return p.state.current.score
+}
+
 // Accept starts a new turn with the remaining dice and
-// score from the previous turn
+// score from the previous turn. A player in a game accepts the game's
+// offer instead, and only on their turn.
 func (p *Player) Accept(dice int, score uint32) {
-	p.current = NewTurn(p.random, WithStart(dice, score))
+	if p.game != nil {
+		_ = p.play("accept", p.game.Accept)
+		return
+	}
+	p.set(p.state.accept(dice, score))
+	p.started()
 }
 
-// Reject starts a new turn with six dice and no score
+func (p playerState) accept(dice int, score uint32) playerState {
+	p.current, p.active = newTurn(dice, score), true
+	return p
+}
+
+// Reject starts a new turn with six dice and no score. A player in a game
+// only can on their turn.
 func (p *Player) Reject() {
-	p.current = NewTurn(p.random)
+	if p.game != nil {
+		_ = p.play("reject", p.game.Reject)
+		return
+	}
+	p.set(p.state.reject())
+	p.started()
+}
+
+func (p playerState) reject() playerState {
+	return p.accept(startDice, 0)
+}
+
+// started logs the start of a turn
+func (p *Player) started() {
+	p.logger.Info("turn started", "dice", p.current.Available(), "score", p.current.Result())
 }
 
 // Roll rolls the available dice in turn
 func (p *Player) Roll() error {
-	if p.current == nil {
-		return fmt.Errorf("no current turn for player %q", p.name)
+	if p.game != nil {
+		return p.play("roll", p.game.Roll)
+	}
+	if err := p.state.rollable(); err != nil {
+		return err
+	}
+	turn, before := p.current, p.Turn()
+	next, err := p.state.roll(draw(p.random, p.state.current.available))
+	if err != nil {
+		return err
 	}
-	p.current.Roll()
-	if p.current.Farkle() {
-		defer p.next(p.current.available, p.current.score)
-		p.turns = append(p.turns, p.current)
-		p.current = nil
+	p.set(next)
+	turn.rolled(before)
+	if !next.active {
+		p.next(0, 0)
 	}
 	return nil
 }
 
+// rollable returns why the player can't roll, or nil when they can
+func (p playerState) rollable() error {
+	if !p.active {
+		return fmt.Errorf("%w: no current turn for player %q", ErrNotYourTurn, p.name)
+	}
+	return p.current.rollable()
+}
+
+func (p playerState) roll(dice Roll) (playerState, error) {
+	if err := p.rollable(); err != nil {
+		return p, err
+	}
+	current, err := p.current.roll(dice)
+	if err != nil {
+		return p, err
+	}
+	p.current = current
+	if current.farkle {
+		p = p.finish()
+	}
+	return p, nil
+}
+
+// finish ends the turn in progress
+func (p playerState) finish() playerState {
+	p.turns = slices.Clip(append(p.turns, p.current))
+	p.current, p.active = turn{}, false
+	return p
+}
+
 // Keep keeps the given dice
 func (p *Player) Keep(dice ...int) error {
-	return p.current.Keep(dice...)
+	if p.game != nil {
+		return p.play("keep", func() error { return p.game.Keep(dice...) })
+	}
+	keep := slices.Clone(dice)
+	before := p.state.current
+	next, err := p.state.keep(dice...)
+	if err != nil {
+		return err
+	}
+	p.set(next)
+	p.current.kept(keep, before)
+	return nil
+}
+
+func (p playerState) keep(dice ...int) (playerState, error) {
+	if !p.active {
+		return p, fmt.Errorf("%w: no current turn for player %q", ErrNotYourTurn, p.name)
+	}
+	current, err := p.current.keep(dice...)
+	if err != nil {
+		return p, err
+	}
+	p.current = current
+	return p, nil
 }
 
 // Bank concludes the current turn
-func (p *Player) Bank() {
-	defer p.next(p.current.available, p.current.score)
-	p.turns = append(p.turns, p.current)
-	p.current = nil
+func (p *Player) Bank() error {
+	if p.game != nil {
+		return p.play("bank", p.game.Bank)
+	}
+	turn := p.current
+	next, err := p.state.bank()
+	if err != nil {
+		return err
+	}
+	p.set(next)
+	turn.banked()
+	p.next(turn.Available(), turn.Result())
+	return nil
+}
+
+func (p playerState) bank() (playerState, error) {
+	if !p.active {
+		return p, fmt.Errorf("%w: no current turn for player %q", ErrNotYourTurn, p.name)
+	}
+	current, err := p.current.bank()
+	if err != nil {
+		return p, err
+	}
+	p.current = current
+	return p.finish(), nil
+}
+
+// Undo takes back the most recent keep in the current turn
+func (p *Player) Undo() error {
+	if p.game != nil {
+		return p.play("undo", func() error {
+			// without dice the game would take back the previous player's bank
+			if p.current == nil || p.current.Dice() == nil {
+				return p.game.refused("undo", fmt.Errorf("%w: no keep this turn for player %q", ErrNothingToUndo, p.state.name))
+			}
+			return p.game.Undo()
+		})
+	}
+	next, err := p.state.undo()
+	if err != nil {
+		return err
+	}
+	p.set(next)
+	p.current.undone()
+	return nil
+}
+
+func (p playerState) undo() (playerState, error) {
+	if !p.active {
+		return p, fmt.Errorf("%w: no current turn for player %q", ErrNothingToUndo, p.name)
+	}
+	current, err := p.current.undone()
+	if err != nil {
+		return p, err
+	}
+	p.current = current
+	return p, nil
+}
+
+// play takes an action of a player in a game through the game, so that it
+// stays in step with them, refusing it unless it is their turn
+func (p *Player) play(action string, take func() error) error {
+	if p.game.Current() != p {
+		return p.game.refused(action, fmt.Errorf("%w: player %q", ErrNotYourTurn, p.state.name))
+	}
+	return take()
+}
+
+// skip ends the player's turn without scoring, abandoning any turn in
+// progress
+func (p playerState) skip() playerState {
+	p.current, p.active = turn{}, false
+	return p
+}
+
+// unbank restores the most recently banked turn as the current turn
+func (p playerState) unbank() (playerState, error) {
+	if len(p.turns) == 0 || !p.turns[len(p.turns)-1].banked {
+		return p, fmt.Errorf("%w for player %q", ErrNothingToUndo, p.name)
+	}
+	p.current, p.active = p.turns[len(p.turns)-1], true
+	p.current.banked = false
+	p.turns = slices.Clip(p.turns[:len(p.turns)-1])
+	return p, nil
+}
+
+// set replaces the player's state, keeping each turn's pointer for as long
+// as the turn lasts, from in progress to finished and back again when a
+// bank is undone
+func (p *Player) set(state playerState) {
+	all := slices.Clip(p.turns)
+	if p.current != nil {
+		all = append(all, p.current)
+	}
+	n := len(state.turns)
+	if state.active {
+		n++
+	}
+	turns := make([]*Turn, n)
+	for k := range n {
+		if k < len(all) {
+			turns[k] = all[k]
+		} else {
+			turns[k] = &Turn{random: p.random, logger: p.logger, player: p}
+		}
+		if k < len(state.turns) {
+			turns[k].state = state.turns[k]
+		} else {
+			turns[k].state = state.current
+		}
+	}
+	p.state, p.turns, p.current = state, turns[:len(state.turns)], nil
+	if state.active {
+		p.current = turns[n-1]
+	}
+}
+
+func NewPlayer(name string, random Random, next func(dice int, score uint32), opts ...PlayerOpt) *Player {
+	player := &Player{state: playerState{name: name}, random: random, next: next, logger: nop}
+	for _, opt := range opts {
+		opt(player)
+	}
+	if player.state.id == "" {
+		player.state.id = newID()
+	}
+	player.logger = player.logger.With("player", player.state.id)
+	return player
 }
 
-func NewPlayer(name string, random Random, next func(dice int, score uint32)) *Player {
-	return &Player{name: name, random: random, next: next}
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
 }
@@ -1,7 +1,10 @@
 package game_test
 
 import (
+	"errors"
+
 	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
 	"github.com/ryannatesmith/farkle/game"
 	"testing"
 )
@@ -51,6 +54,17 @@ func TestPlayer_Roll(t *testing.T) {
 	}
 }
 
+func TestPlayer_NoTurn(t *testing.T) {
+	t.Parallel()
+	player := game.NewPlayer("test", func() uint8 { return 3 }, func(dice int, score uint32) {})
+	if err := player.Keep(0); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Error("unexpected error", err)
+	}
+	if err := player.Bank(); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Error("unexpected error", err)
+	}
+}
+
 func TestPlayer_Next(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -65,7 +79,7 @@ func TestPlayer_Next(t *testing.T) {
 			play: func(next func(int, uint32)) {
 				player := game.NewPlayer(
 					"test",
-					random([]uint8{1,1,1,5,2,3}),
+					farkletest.NewDice(1,1,1,5,2,3).Roll,
 					next,
 					)
 				player.Reject()
@@ -81,7 +95,7 @@ func TestPlayer_Next(t *testing.T) {
 			play: func(next func(int, uint32)) {
 				player := game.NewPlayer(
 					"test",
-					random([]uint8{2,3,4,6,4,3}),
+					farkletest.NewDice(2,3,4,6,4,3).Roll,
 					next,
 					)
 				player.Reject()
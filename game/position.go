@@ -0,0 +1,366 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Position is a game at one point, as a value. Positions are never
+// changed, so they can be shared between goroutines and branched from
+// freely: Apply returns a new position and leaves the one it was given as
+// it was.
+type Position struct {
+	players    []playerState
+	current    int
+	dice       int
+	score      uint32
+	target     uint32
+	opening    uint32
+	noUndo     bool
+	finalRound bool
+	last       int
+	over       bool
+	started    bool
+	// skipped is set when the previous player skipped their turn, leaving
+	// no bank to undo
+	skipped bool
+}
+
+// Action is something the current player does. A roll carries the dice
+// thrown, and a keep the indexes of the dice kept.
+type Action struct {
+	Type EventType
+	Dice Roll
+	Keep []int
+}
+
+// Apply returns the position after the current player takes the action. The
+// action is accepted, rejected, rolled, kept, banked, undone or skipped.
+func Apply(p Position, action Action) (Position, error) {
+	switch action.Type {
+	case EventAccepted:
+		return p.accept()
+	case EventRejected:
+		return p.reject()
+	case EventRolled:
+		return p.roll(action.Dice)
+	case EventKept:
+		return p.keep(action.Keep...)
+	case EventBanked:
+		return p.bank()
+	case EventUndone:
+		return p.undo()
+	case EventSkipped:
+		return p.skip()
+	default:
+		return p, fmt.Errorf("%w %q", ErrUnknownAction, action.Type)
+	}
+}
+
+// State returns the stage the game has reached
+func (p Position) State() State {
+	switch {
+	case p.over:
+		return GameOver
+	case len(p.players) == 0 || !p.players[p.current].active:
+		return AwaitingDecision
+	default:
+		return p.players[p.current].current.stage()
+	}
+}
+
+// Players returns the number of players
+func (p Position) Players() int {
+	return len(p.players)
+}
+
+// Current returns the seat of the player whose turn it is
+func (p Position) Current() int {
+	return p.current
+}
+
+// Score returns the score of the player in the seat, including any
+// starting score from their handicap
+func (p Position) Score(seat int) uint32 {
+	return p.players[seat].score()
+}
+
+// Standing returns the score the player in the seat is ranked by
+func (p Position) Standing(seat int) uint32 {
+	return p.standing(p.players[seat])
+}
+
+// Offer returns the dice and score left by the previous player
+func (p Position) Offer() (int, uint32) {
+	return p.dice, p.score
+}
+
+// Dice returns the current turn's most recent roll
+func (p Position) Dice() Roll {
+	return p.turn().dice
+}
+
+// Held returns the indexes of the dice kept from the most recent roll
+func (p Position) Held() []int {
+	return p.turn().held
+}
+
+// Turn returns the score of the current turn so far
+func (p Position) Turn() uint32 {
+	return p.turn().score
+}
+
+// Available returns the number of dice the current player has to roll
+func (p Position) Available() int {
+	return p.turn().available
+}
+
+// Target returns the score that triggers the final round
+func (p Position) Target() uint32 {
+	if p.target == 0 {
+		return defaultTarget
+	}
+	return p.target
+}
+
+// FinalRound returns the seat of the player who reached the target, when
+// they have
+func (p Position) FinalRound() (int, bool) {
+	return p.last, p.finalRound
+}
+
+// Over reports whether every player has had their final turn
+func (p Position) Over() bool {
+	return p.over
+}
+
+// Winner returns the seat of the player furthest past their target, or -1
+// until the game is over
+func (p Position) Winner() int {
+	if !p.over {
+		return -1
+	}
+	winner := 0
+	for seat, player := range p.players {
+		if p.compare(player, p.players[winner]) > 0 {
+			winner = seat
+		}
+	}
+	return winner
+}
+
+// turn returns the current turn, or none between turns
+func (p Position) turn() turn {
+	if len(p.players) == 0 || !p.players[p.current].active {
+		return turn{}
+	}
+	return p.players[p.current].current
+}
+
+// with returns the position with the player in the seat replaced
+func (p Position) with(seat int, player playerState) Position {
+	p.players = slices.Clone(p.players)
+	p.players[seat] = player
+	return p
+}
+
+// deciding returns the current player when they are yet to accept or
+// reject the previous player's dice
+func (p Position) deciding() (playerState, error) {
+	switch p.State() {
+	case GameOver:
+		return playerState{}, ErrGameOver
+	case AwaitingDecision:
+		if err := p.ready(); err != nil {
+			return playerState{}, err
+		}
+		return p.players[p.current], nil
+	default:
+		return playerState{}, ErrTurnInProgress
+	}
+}
+
+// playing returns the current player when their turn is in progress
+func (p Position) playing() (playerState, error) {
+	switch p.State() {
+	case GameOver:
+		return playerState{}, ErrGameOver
+	case AwaitingDecision:
+		if err := p.ready(); err != nil {
+			return playerState{}, err
+		}
+		return playerState{}, ErrMustDecide
+	default:
+		return p.players[p.current], nil
+	}
+}
+
+// ready returns why no player can act yet, or nil once the game has started
+func (p Position) ready() error {
+	switch {
+	case len(p.players) == 0:
+		return ErrNoPlayers
+	case !p.started:
+		return ErrNotStarted
+	}
+	return nil
+}
+
+func (p Position) accept() (Position, error) {
+	player, err := p.deciding()
+	if err != nil {
+		return p, err
+	}
+	if p.dice == 0 {
+		return p, ErrNothingToAccept
+	}
+	return p.with(p.current, player.accept(p.dice, p.score)), nil
+}
+
+func (p Position) reject() (Position, error) {
+	player, err := p.deciding()
+	if err != nil {
+		return p, err
+	}
+	return p.with(p.current, player.reject()), nil
+}
+
+// rollable returns why the current player can't roll, or nil when they can
+func (p Position) rollable() error {
+	player, err := p.playing()
+	if err != nil {
+		return err
+	}
+	return player.rollable()
+}
+
+func (p Position) roll(dice Roll) (Position, error) {
+	player, err := p.playing()
+	if err != nil {
+		return p, err
+	}
+	if player, err = player.roll(dice); err != nil {
+		return p, err
+	}
+	p = p.with(p.current, player)
+	if !player.active {
+		p = p.next(0, 0)
+	}
+	return p, nil
+}
+
+func (p Position) keep(dice ...int) (Position, error) {
+	player, err := p.playing()
+	if err != nil {
+		return p, err
+	}
+	if player, err = player.keep(dice...); err != nil {
+		return p, err
+	}
+	return p.with(p.current, player), nil
+}
+
+func (p Position) bank() (Position, error) {
+	player, err := p.playing()
+	if err != nil {
+		return p, err
+	}
+	turn := player.current
+	if opening := p.openingFor(player); turn.stage() == AwaitingRoll && turn.score < opening {
+		return p, fmt.Errorf("%w: %d of %d", ErrBelowOpening, turn.score, opening)
+	}
+	if player, err = player.bank(); err != nil {
+		return p, err
+	}
+	return p.with(p.current, player).next(turn.available, turn.score), nil
+}
+
+func (p Position) skip() (Position, error) {
+	if p.over {
+		return p, ErrGameOver
+	}
+	if err := p.ready(); err != nil {
+		return p, err
+	}
+	p = p.with(p.current, p.players[p.current].skip()).next(0, 0)
+	p.skipped = true
+	return p, nil
+}
+
+// undo takes back the most recent keep, or the previous player's bank when
+// the current player has not yet rolled
+func (p Position) undo() (Position, error) {
+	switch {
+	case p.noUndo:
+		return p, ErrUndoDisabled
+	case p.over:
+		return p, ErrGameOver
+	}
+	if err := p.ready(); err != nil {
+		return p, err
+	}
+	player := p.players[p.current]
+	if player.active && player.current.dice != nil {
+		player, err := player.undo()
+		if err != nil {
+			return p, err
+		}
+		return p.with(p.current, player), nil
+	}
+	if p.skipped {
+		return p, fmt.Errorf("%w: the previous turn was skipped", ErrNothingToUndo)
+	}
+	previous := (p.current + len(p.players) - 1) % len(p.players)
+	unbanked, err := p.players[previous].unbank()
+	if err != nil {
+		return p, err
+	}
+	if previous != p.current {
+		p = p.with(p.current, player.skip())
+	}
+	p = p.with(previous, unbanked)
+	p.current = previous
+	p.dice, p.score = 0, 0
+	if p.finalRound && p.last == previous {
+		p.finalRound = false
+	}
+	return p, nil
+}
+
+// next moves play to the next player, offering them the remaining dice
+// and score from the turn that just finished. Once a player reaches the
+// target every other player has one more turn.
+func (p Position) next(dice int, score uint32) Position {
+	if player := p.players[p.current]; !p.finalRound && p.standing(player) >= p.targetFor(player) {
+		p.finalRound = true
+		p.last = p.current
+	}
+	p.dice, p.score, p.skipped = dice, score, false
+	p.current = (p.current + 1) % len(p.players)
+	if p.finalRound && p.current == p.last {
+		p.over = true
+	}
+	return p
+}
+
+// start begins the first player's turn, returning the seat each player
+// moved from when a team game seats them
+func (p Position) start() (Position, []int, error) {
+	if len(p.players) == 0 {
+		return p, nil, ErrNoPlayers
+	}
+	order, err := seating(p.players)
+	if err != nil {
+		return p, nil, err
+	}
+	if order != nil {
+		players := make([]playerState, len(order))
+		for i, j := range order {
+			players[i] = p.players[j]
+		}
+		p.players = players
+	}
+	p.current, p.started, p.skipped = 0, true, false
+	p.dice, p.score = 0, 0
+	return p.with(0, p.players[0].reject()), order, nil
+}
@@ -0,0 +1,172 @@
+package game_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// started returns the position of a two player game whose first player has
+// rolled 1 1 1 5 4 2
+func started(t *testing.T, opts ...game.GameOpt) game.Position {
+	t.Helper()
+	g := game.NewGame(append(opts, game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll))...)
+	g.Join("one", game.WithID("a"))
+	g.Join("two", game.WithID("b"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	return g.Position()
+}
+
+func TestApply_Branches(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		keep  []int
+		dice  int
+		score uint32
+	}
+	cases := []testCase{
+		{keep: []int{0, 1, 2, 3}, dice: 2, score: 350},
+		{keep: []int{0, 1, 2}, dice: 3, score: 300},
+		{keep: []int{3}, dice: 5, score: 50},
+	}
+	s := started(t)
+	got := make([]game.Position, len(cases))
+	errs := make([]error, len(cases))
+	var wg sync.WaitGroup
+	for i, c := range cases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			branch, err := game.Apply(s, game.Action{Type: game.EventKept, Keep: c.keep})
+			if err == nil {
+				branch, err = game.Apply(branch, game.Action{Type: game.EventBanked})
+			}
+			got[i], errs[i] = branch, err
+		}()
+	}
+	wg.Wait()
+	for i, c := range cases {
+		if errs[i] != nil {
+			t.Fatalf("keep %v: %v", c.keep, errs[i])
+		}
+		if dice, score := got[i].Offer(); dice != c.dice || score != c.score {
+			t.Errorf("keep %v: +want -got\n\t+%d/%d\n\t-%d/%d", c.keep, c.dice, c.score, dice, score)
+		}
+		if got[i].Current() != 1 {
+			t.Errorf("keep %v: still seat %d to play", c.keep, got[i].Current())
+		}
+	}
+	if s.State() != game.AwaitingKeep || s.Current() != 0 || s.Turn() != 0 || s.Score(0) != 0 {
+		t.Errorf("branching changed the position: %v, seat %d, turn %d, score %d", s.State(), s.Current(), s.Turn(), s.Score(0))
+	}
+}
+
+func TestApply_Game(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 5, 3).Roll), game.WithTarget(300))
+	g.Join("one", game.WithID("a"))
+	g.Join("two", game.WithID("b"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	s := g.Position()
+	for _, step := range []struct {
+		action game.Action
+		play   func() error
+	}{
+		{game.Action{Type: game.EventRolled, Dice: game.Roll{1, 1, 1, 5, 4, 2}}, g.Roll},
+		{game.Action{Type: game.EventKept, Keep: []int{0, 1, 2, 3}}, func() error { return g.Keep(0, 1, 2, 3) }},
+		{game.Action{Type: game.EventBanked}, g.Bank},
+		{game.Action{Type: game.EventAccepted}, g.Accept},
+		{game.Action{Type: game.EventRolled, Dice: game.Roll{5, 3}}, g.Roll},
+		{game.Action{Type: game.EventUndone}, g.Undo},
+		{game.Action{Type: game.EventKept, Keep: []int{0}}, func() error { return g.Keep(0) }},
+		{game.Action{Type: game.EventBanked}, g.Bank},
+		{game.Action{Type: game.EventRejected}, g.Reject},
+	} {
+		next, want := game.Apply(s, step.action)
+		if got := step.play(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("%s: +want -got\n\t+%v\n\t-%v", step.action.Type, want, got)
+		}
+		if want == nil {
+			s = next
+		}
+		if diff := cmp.Diff(summary(s), summary(g.Position())); diff != "" {
+			t.Fatalf("%s: +want -got\n%s", step.action.Type, diff)
+		}
+	}
+	if !s.Over() || s.Winner() != 1 {
+		t.Errorf("game over %t, winner %d", s.Over(), s.Winner())
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		opts   []game.GameOpt
+		action game.Action
+		want   error
+	}
+	for _, c := range []testCase{
+		{name: "unknown action", action: game.Action{Type: game.EventJoined}, want: game.ErrUnknownAction},
+		{name: "roll before keeping", action: game.Action{Type: game.EventRolled, Dice: game.Roll{1}}, want: game.ErrMustKeepBeforeRoll},
+		{name: "keep a non-scoring die", action: game.Action{Type: game.EventKept, Keep: []int{4}}, want: game.ErrInvalidKeep},
+		{name: "undo disabled", opts: []game.GameOpt{game.WithoutUndo()}, action: game.Action{Type: game.EventUndone}, want: game.ErrUndoDisabled},
+		{name: "accept mid-turn", action: game.Action{Type: game.EventAccepted}, want: game.ErrTurnInProgress},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			s := started(t, c.opts...)
+			got, err := game.Apply(s, c.action)
+			if !errors.Is(err, c.want) {
+				t.Errorf("error: +want -got\n\t+%v\n\t-%v", c.want, err)
+			}
+			if diff := cmp.Diff(summary(s), summary(got)); diff != "" {
+				t.Errorf("refused action changed the position: +want -got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApply_InvalidRoll(t *testing.T) {
+	t.Parallel()
+	s := started(t)
+	s, err := game.Apply(s, game.Action{Type: game.EventKept, Keep: []int{0, 1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.Apply(s, game.Action{Type: game.EventRolled, Dice: game.Roll{5}}); !errors.Is(err, game.ErrInvalidRoll) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrInvalidRoll, err)
+	}
+}
+
+// summary is what can be seen of a position from outside the package
+func summary(s game.Position) map[string]any {
+	dice, score := s.Offer()
+	scores := make([]uint32, s.Players())
+	for seat := range scores {
+		scores[seat] = s.Score(seat)
+	}
+	return map[string]any{
+		"stage":     s.State().String(),
+		"current":   s.Current(),
+		"offer":     [2]uint32{uint32(dice), score},
+		"dice":      s.Dice(),
+		"held":      s.Held(),
+		"turn":      s.Turn(),
+		"available": s.Available(),
+		"scores":    scores,
+		"over":      s.Over(),
+	}
+}
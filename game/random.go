@@ -9,3 +9,11 @@ func NewRandom() Random {
 		return uint8(rand.Uint32N(6) + 1)
 	}
 }
+
+// NewSeededRandom returns dice that roll the same sequence for the same seed
+func NewSeededRandom(seed uint64) Random {
+	r := rand.New(rand.NewPCG(seed, seed))
+	return func() uint8 {
+		return uint8(r.Uint32N(6) + 1)
+	}
+}
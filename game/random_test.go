@@ -14,3 +14,13 @@ func TestRandom(t *testing.T) {
 		}
 	}
 }
+
+func TestNewSeededRandom(t *testing.T) {
+	t.Parallel()
+	a, b := game.NewSeededRandom(42), game.NewSeededRandom(42)
+	for range 1_000 {
+		if got, want := a(), b(); got != want || got < 1 || got > 6 {
+			t.Errorf("unexpected random %d, want %d", got, want)
+		}
+	}
+}
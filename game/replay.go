@@ -0,0 +1,65 @@
+package game
+
+import "fmt"
+
+// Replay rebuilds a game from its events, rolling the recorded dice.
+// Further rolls use the dice given in opts. Listeners given in opts
+// only hear events that happen after the replay.
+func Replay(events []Event, opts ...GameOpt) (*Game, error) {
+	g := NewGame(opts...)
+	listeners := g.listeners
+	g.listeners = nil
+	for i, event := range events {
+		if err := g.ApplyEvent(event); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", i, event.Type, err)
+		}
+	}
+	g.listeners = listeners
+	return g, nil
+}
+
+// ApplyEvent performs the action recorded in an event, rolling the
+// recorded dice. Events that follow from other actions, such as farkles,
+// are ignored. Unlike Apply, which takes a Position and an Action, it acts
+// on the game and checks the event is the current player's.
+func (g *Game) ApplyEvent(event Event) error {
+	switch event.Type {
+	case EventJoined:
+		opts := []PlayerOpt{WithID(event.Player), WithTeam(event.Team)}
+		if event.Handicap != nil {
+			opts = append(opts, WithHandicap(*event.Handicap))
+		}
+		g.Join(event.Name, opts...)
+		return nil
+	case EventStarted:
+		g.position.target, g.position.opening = event.Score, event.Opening
+		g.position.noUndo = g.position.noUndo || event.NoUndo
+		return g.Start()
+	case EventFarkle, EventEnded:
+		return nil
+	}
+	if len(g.players) == 0 {
+		return ErrNoPlayers
+	}
+	if event.Type != EventUndone && event.Player != g.Current().ID() {
+		return fmt.Errorf("%w: %q", ErrNotYourTurn, event.Player)
+	}
+	switch event.Type {
+	case EventAccepted:
+		return g.Accept()
+	case EventRejected:
+		return g.Reject()
+	case EventRolled:
+		return g.roll(event.Dice)
+	case EventKept:
+		return g.Keep(event.Keep...)
+	case EventBanked:
+		return g.Bank()
+	case EventUndone:
+		return g.Undo()
+	case EventSkipped:
+		return g.Skip()
+	default:
+		return fmt.Errorf("unknown event %q", event.Type)
+	}
+}
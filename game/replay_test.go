@@ -0,0 +1,70 @@
+package game_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	var events []game.Event
+	g := game.NewGame(
+		game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 5, 3, 2, 2, 3, 4, 6, 4, 3).Roll),
+		game.WithTarget(300),
+		game.WithListener(func(e game.Event) { events = append(events, e) }),
+	)
+	g.Join("one", game.WithID("1"))
+	g.Join("two", game.WithID("2"))
+	for _, step := range []func() error{
+		g.Start,
+		g.Roll,
+		func() error { return g.Keep(0, 1, 2) },
+		func() error { return g.Keep(3) },
+		g.Undo,
+		g.Roll,
+		func() error { return g.Keep(0) },
+		g.Bank,
+		g.Reject,
+		g.Roll,
+	} {
+		if err := step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := json.Marshal(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []game.Event
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	var replayed []game.Event
+	got, err := game.Replay(decoded, game.WithListener(func(e game.Event) { replayed = append(replayed, e) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != 0 {
+		t.Error("listeners should not hear replayed events", replayed)
+	}
+	if !got.Over() || got.Winner().ID() != "1" || got.Winner().Score() != 350 {
+		t.Errorf("unexpected replayed game, winner %q with %d", got.Winner().ID(), got.Winner().Score())
+	}
+	if diff := cmp.Diff(events, decoded); diff != "" {
+		t.Error("+want -got", diff)
+	}
+	decoded[3].Dice = game.Roll{2, 3, 4, 6, 6, 2}
+	if _, err := game.Replay(decoded); err == nil {
+		t.Error("should have got error for altered dice")
+	}
+	outOfTurn := append(slices.Clone(events[:3]), game.Event{Type: game.EventRolled, Player: "2", Dice: game.Roll{1, 1, 1, 5, 4, 2}})
+	if _, err := game.Replay(outOfTurn); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", game.ErrNotYourTurn, err)
+	}
+}
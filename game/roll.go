@@ -1,10 +1,94 @@
 package game
 
-import "sort"
+import (
+  "encoding/json"
+  "fmt"
+  "sort"
+  "sync"
+)
+
+// maxTable is the most dice in a roll whose scorings are looked up rather
+// than worked out
+const maxTable = 6
 
 type Roll []uint8
 
+// Score returns every way of scoring dice from the roll, highest first.
+// Each call returns new scorings the caller is free to change.
 func (r Roll) Score() []*Scoring {
+  scorings, ok := lookup(r)
+  if !ok {
+    return r.score()
+  }
+  ret := make([]*Scoring, len(scorings))
+  for i, scoring := range scorings {
+    ret[i] = &Scoring{Score: scoring.Score, Set: append([]int(nil), scoring.Set...)}
+  }
+  return ret
+}
+
+// Scorings returns the same scorings as Score, in the same order, without
+// allocating for rolls of up to six dice. They are shared by every caller
+// and must not be changed.
+func (r Roll) Scorings() []Scoring {
+  if scorings, ok := lookup(r); ok {
+    return scorings
+  }
+  scorings := r.score()
+  ret := make([]Scoring, len(scorings))
+  for i, scoring := range scorings {
+    ret[i] = *scoring
+  }
+  return ret
+}
+
+// Farkle reports whether no dice of the roll score
+func (r Roll) Farkle() bool {
+  if counts, ok := r.Counts(); ok {
+    return counts.Farkle()
+  }
+  return len(r.score()) == 0
+}
+
+// Counts returns the number of dice showing each face, and false if the
+// roll has more than six dice or a die that isn't 1 to 6
+func (r Roll) Counts() (Counts, bool) {
+  var counts Counts
+  if len(r) > maxTable {
+    return counts, false
+  }
+  for _, d := range r {
+    if d < 1 || d > 6 {
+      return counts, false
+    }
+    counts[d]++
+  }
+  return counts, true
+}
+
+// Counts is a roll of up to six dice regardless of order, Counts[d] being
+// the number showing face d
+type Counts [7]uint8
+
+// Farkle reports whether none of the dice score
+func (c Counts) Farkle() bool {
+  if c[1] > 0 || c[5] > 0 {
+    return false
+  }
+  pairs := 0
+  for _, n := range c[2:] {
+    switch {
+    case n >= 3:
+      return false
+    case n == 2:
+      pairs++
+    }
+  }
+  return pairs < 3
+}
+
+// score works out the scorings of the roll with each scorer in turn
+func (r Roll) score() []*Scoring {
   values := values(r)
   ret := make([]*Scoring, 0)
   for _, s := range []Scorer{
@@ -21,9 +105,82 @@ func (r Roll) Score() []*Scoring {
     if scoring := s(values); scoring != nil {
       ret = append(ret, scoring...)
     }
-    sort.Slice(ret, func(i, j int) bool {
+    sort.SliceStable(ret, func(i, j int) bool {
       return ret[i].Score > ret[j].Score
     })
   }
   return ret
 }
+
+// table holds the scorings of every roll of up to six dice, those of n
+// dice starting at offsets[n] and ordered as base six numbers
+type table struct {
+  offsets  [maxTable + 2]int
+  scorings [][]Scoring
+}
+
+var scoringTable = sync.OnceValue(func() *table {
+  t := &table{}
+  size := 1
+  for n := range maxTable + 1 {
+    t.offsets[n+1] = t.offsets[n] + size
+    size *= 6
+  }
+  t.scorings = make([][]Scoring, t.offsets[maxTable+1])
+  for n := range maxTable + 1 {
+    roll := make(Roll, n)
+    for i := range t.offsets[n+1] - t.offsets[n] {
+      for j, k := n-1, i; j >= 0; j, k = j-1, k/6 {
+        roll[j] = uint8(k%6) + 1
+      }
+      scorings := roll.score()
+      entry := make([]Scoring, len(scorings))
+      for j, scoring := range scorings {
+        entry[j] = Scoring{Score: scoring.Score, Set: append([]int(nil), scoring.Set...)}
+      }
+      t.scorings[t.offsets[n]+i] = entry
+    }
+  }
+  return t
+})
+
+// lookup returns the scorings of the roll from the table, and false if it
+// isn't in it
+func lookup(r Roll) ([]Scoring, bool) {
+  if len(r) > maxTable {
+    return nil, false
+  }
+  i := 0
+  for _, d := range r {
+    if d < 1 || d > 6 {
+      return nil, false
+    }
+    i = i*6 + int(d-1)
+  }
+  t := scoringTable()
+  return t.scorings[t.offsets[len(r)]+i], true
+}
+
+// MarshalJSON writes the roll as a list of numbers rather than as bytes
+func (r Roll) MarshalJSON() ([]byte, error) {
+  dice := make([]int, len(r))
+  for i, d := range r {
+    dice[i] = int(d)
+  }
+  return json.Marshal(dice)
+}
+
+func (r *Roll) UnmarshalJSON(b []byte) error {
+  var dice []int
+  if err := json.Unmarshal(b, &dice); err != nil {
+    return err
+  }
+  *r = make(Roll, len(dice))
+  for i, d := range dice {
+    if d < 1 || d > 6 {
+      return fmt.Errorf("invalid die %d", d)
+    }
+    (*r)[i] = uint8(d)
+  }
+  return nil
+}
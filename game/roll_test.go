@@ -166,6 +166,16 @@ func TestRoll_Score(t *testing.T) {
       roll: []uint8{2, 2, 2, 2},
       want: []*game.Scoring{{Score: 1000, Set: []int{0, 1, 2, 3}}},
     },
+    {
+      name: "re-roll four, get two pairs",
+      roll: []uint8{3, 3, 5, 5},
+      want: []*game.Scoring{{Score: 50, Set: []int{2}}, {Score: 50, Set: []int{3}}},
+    },
+    {
+      name: "re-roll four, get two pairs that don't score",
+      roll: []uint8{2, 2, 3, 3},
+      want: []*game.Scoring{},
+    },
     {
       name: "straight",
       roll: []uint8{1, 2, 3, 4, 5, 6},
@@ -185,3 +195,90 @@ func TestRoll_Score(t *testing.T) {
     })
   }
 }
+
+func TestRoll_Scorings(t *testing.T) {
+  t.Parallel()
+  for n := 0; n <= 6; n++ {
+    every(n, func(roll game.Roll) {
+      want := game.Computed(roll)
+      if diff := cmp.Diff(want, roll.Score()); diff != "" {
+        t.Fatalf("%v: score: +want -got\n%s", roll, diff)
+      }
+      scorings := roll.Scorings()
+      if len(want) != len(scorings) {
+        t.Fatalf("%v: %d scorings, want %d", roll, len(scorings), len(want))
+      }
+      for i, scoring := range scorings {
+        if diff := cmp.Diff(*want[i], scoring); diff != "" {
+          t.Fatalf("%v: scoring %d: +want -got\n%s", roll, i, diff)
+        }
+      }
+      if got := roll.Farkle(); got != (len(want) == 0) {
+        t.Fatalf("%v: farkle %t, want %t", roll, got, !got)
+      }
+    })
+  }
+}
+
+func TestRoll_ScoreCopies(t *testing.T) {
+  t.Parallel()
+  roll := game.Roll{1, 1, 1, 5, 4, 3}
+  score := roll.Score()
+  score[0].Score, score[0].Set[0] = 0, 5
+  if diff := cmp.Diff(roll.Score()[0], &game.Scoring{Score: 300, Set: []int{0, 1, 2}}); diff != "" {
+    t.Errorf("changing a scoring changed the next: +want -got\n%s", diff)
+  }
+}
+
+func TestRoll_Allocations(t *testing.T) {
+  roll := game.Roll{1, 1, 1, 5, 4, 3}
+  for name, f := range map[string]func(){
+    "scorings": func() { roll.Scorings() },
+    "farkle":   func() { roll.Farkle() },
+  } {
+    if allocs := testing.AllocsPerRun(100, f); allocs != 0 {
+      t.Errorf("%s: %v allocations", name, allocs)
+    }
+  }
+}
+
+// rolls returns a spread of rolls of six dice to benchmark with
+func rolls() []game.Roll {
+  ret := make([]game.Roll, 0, 1024)
+  random := game.NewSeededRandom(1)
+  for range cap(ret) {
+    roll := make(game.Roll, 6)
+    for i := range roll {
+      roll[i] = random()
+    }
+    ret = append(ret, roll)
+  }
+  return ret
+}
+
+func BenchmarkRoll_Score(b *testing.B) {
+  rolls := rolls()
+  b.ReportAllocs()
+  b.ResetTimer()
+  for i := range b.N {
+    rolls[i%len(rolls)].Score()
+  }
+}
+
+func BenchmarkRoll_Scorings(b *testing.B) {
+  rolls := rolls()
+  b.ReportAllocs()
+  b.ResetTimer()
+  for i := range b.N {
+    rolls[i%len(rolls)].Scorings()
+  }
+}
+
+func BenchmarkRoll_Farkle(b *testing.B) {
+  rolls := rolls()
+  b.ReportAllocs()
+  b.ResetTimer()
+  for i := range b.N {
+    rolls[i%len(rolls)].Farkle()
+  }
+}
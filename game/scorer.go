@@ -1,5 +1,10 @@
 package game
 
+import (
+  "maps"
+  "slices"
+)
+
 var (
   all = []int{0, 1, 2, 3, 4, 5}
 )
@@ -56,6 +61,9 @@ func ThreeDoubles() Scorer {
           return nil
         }
       }
+      if count(values) != len(all) {
+        return nil
+      }
       return []*Scoring{{Score: 1_500, Set: all}}
     case 3:
       for _, v := range values {
@@ -113,8 +121,8 @@ func Straight() Scorer {
 func xOfAKind(n int, score func(k uint8) uint32) Scorer {
   return func(values map[uint8][]int) []*Scoring {
     ret := make([]*Scoring, 0)
-    for k, v := range values {
-      if len(v) == n {
+    for _, k := range slices.Sorted(maps.Keys(values)) {
+      if v := values[k]; len(v) == n {
         ret = append(ret, &Scoring{Set: v, Score: score(k)})
       }
     }
@@ -125,6 +133,14 @@ func xOfAKind(n int, score func(k uint8) uint32) Scorer {
   }
 }
 
+func count(values map[uint8][]int) int {
+  var n int
+  for _, v := range values {
+    n += len(v)
+  }
+  return n
+}
+
 func values(r Roll) map[uint8][]int {
   values := make(map[uint8][]int)
   for idx, c := range r {
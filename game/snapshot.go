@@ -0,0 +1,63 @@
+package game
+
+import "slices"
+
+// Snapshot is a read-only copy of a game's state at a point in time,
+// sharing nothing with the game it was taken from
+type Snapshot struct {
+	ID      string           `json:"id"`
+	State   State            `json:"state"`
+	Target  uint32           `json:"target"`
+	Players []PlayerSnapshot `json:"players"`
+	// Current is the seat of the player whose turn it is
+	Current int `json:"current"`
+	// FinalRound reports whether a player has reached the target, Last
+	// being their seat
+	FinalRound bool `json:"finalRound,omitempty"`
+	Last       int  `json:"last,omitempty"`
+	// OfferDice and OfferScore are what the previous player left
+	OfferDice  int    `json:"offerDice,omitempty"`
+	OfferScore uint32 `json:"offerScore,omitempty"`
+	// Dice is the current turn's most recent roll, Held the indexes of
+	// the dice kept from it
+	Dice      Roll   `json:"dice,omitempty"`
+	Held      []int  `json:"held,omitempty"`
+	Turn      uint32 `json:"turn,omitempty"`
+	Available int    `json:"available,omitempty"`
+	// Winner is the seat of the winner once the game is over, or -1
+	Winner int `json:"winner"`
+}
+
+type PlayerSnapshot struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Team  string `json:"team,omitempty"`
+	Score uint32 `json:"score"`
+	Turns int    `json:"turns"`
+	// Target is the score the player must reach, after their handicap
+	Target   uint32   `json:"target"`
+	Handicap Handicap `json:"handicap"`
+}
+
+// Snapshot returns a copy of the game's state for those watching it
+func (g *Game) Snapshot() Snapshot {
+	s := Snapshot{ID: g.id, State: g.State(), Target: g.Target(), Current: g.position.current, Winner: -1}
+	if last, ok := g.FinalRound(); ok {
+		s.FinalRound, s.Last = true, last
+	}
+	s.OfferDice, s.OfferScore = g.Offer()
+	for seat, player := range g.players {
+		s.Players = append(s.Players, PlayerSnapshot{ID: player.ID(), Name: player.Name(), Team: player.Team(), Score: player.Score(), Turns: len(player.Turns()), Target: g.TargetFor(player), Handicap: player.Handicap()})
+		if g.Over() && player == g.Winner() {
+			s.Winner = seat
+		}
+	}
+	if len(g.players) == 0 {
+		return s
+	}
+	if turn := g.Current().Current(); turn != nil {
+		s.Dice, s.Held = slices.Clone(turn.Dice()), slices.Clone(turn.Held())
+		s.Turn, s.Available = turn.Result(), turn.Available()
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+package game
+
+// State is a stage in the flow of a turn or game
+type State int
+
+const (
+	// AwaitingDecision is waiting for the player to accept or reject the
+	// dice left by the previous player
+	AwaitingDecision State = iota
+	AwaitingRoll
+	AwaitingKeep
+	Banked
+	Farkled
+	GameOver
+)
+
+func (s State) String() string {
+	switch s {
+	case AwaitingDecision:
+		return "awaiting accept or reject"
+	case AwaitingRoll:
+		return "awaiting roll"
+	case AwaitingKeep:
+		return "awaiting keep"
+	case Banked:
+		return "banked"
+	case Farkled:
+		return "farkled"
+	case GameOver:
+		return "game over"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import "slices"
+
+// Team is the players sharing a total score in a team game
+type Team struct {
+	Name    string
+	Players []*Player
+}
+
+// Score returns the team's total
+func (t Team) Score() uint32 {
+	var sum uint32
+	for _, player := range t.Players {
+		sum += player.Score()
+	}
+	return sum
+}
+
+// Teams returns the teams in the order they first take a turn, each
+// listing its players in turn order. It is empty unless players joined
+// teams.
+func (g *Game) Teams() []Team {
+	var ret []Team
+	for _, player := range g.players {
+		if player.state.team == "" {
+			continue
+		}
+		i := slices.IndexFunc(ret, func(t Team) bool { return t.Name == player.state.team })
+		if i < 0 {
+			i = len(ret)
+			ret = append(ret, Team{Name: player.state.team})
+		}
+		ret[i].Players = append(ret[i].Players, player)
+	}
+	return ret
+}
+
+// Standing returns the score the player is ranked by: their team's total
+// in a team game, otherwise their own score
+func (g *Game) Standing(player *Player) uint32 {
+	return g.position.standing(player.state)
+}
+
+func (p Position) standing(player playerState) uint32 {
+	if player.team == "" {
+		return player.score()
+	}
+	var sum uint32
+	for _, other := range p.players {
+		if other.team == player.team {
+			sum += other.score()
+		}
+	}
+	return sum
+}
+
+// seating returns the order to seat the players of a team game in, by
+// their current seats, so that turns alternate between the teams and
+// rotate within each. It is nil unless players joined teams.
+func seating(players []playerState) ([]int, error) {
+	var teams [][]int
+	var names []string
+	for seat, player := range players {
+		if player.team == "" {
+			continue
+		}
+		i := slices.Index(names, player.team)
+		if i < 0 {
+			i = len(teams)
+			names = append(names, player.team)
+			teams = append(teams, nil)
+		}
+		teams[i] = append(teams[i], seat)
+	}
+	if len(teams) == 0 {
+		return nil, nil
+	}
+	size := len(teams[0])
+	count := 0
+	for _, team := range teams {
+		if len(team) != size {
+			return nil, ErrUnevenTeams
+		}
+		count += size
+	}
+	if len(teams) < 2 || count != len(players) {
+		return nil, ErrUnevenTeams
+	}
+	order := make([]int, 0, count)
+	for i := range size {
+		for _, team := range teams {
+			order = append(order, team[i])
+		}
+	}
+	return order, nil
+}
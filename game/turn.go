@@ -2,74 +2,263 @@ package game
 
 import (
   "fmt"
+  "log/slog"
   "slices"
-  "sort"
 )
 
 const (
   startDice = 6
+  maxUndo   = 6
 )
 
 type Opt func(*Turn)
 
 func WithStart(dice int, score uint32) Opt {
   return func(turn *Turn) {
-    turn.available = dice
-    turn.score = score
+    turn.state.available = dice
+    turn.state.score = score
   }
 }
 
+// Turn plays a turn with its own dice. Each action replaces its state, an
+// immutable turn value. The turns of a player are played through the
+// player, so that they stay in step.
 type Turn struct {
-  available   int
-  currentRoll Roll
-  rolls       []Roll
-  random      func() uint8
-  score       uint32
-  farkle      bool
+  state  turn
+  random func() uint8
+  logger *slog.Logger
+  // player is the player whose turn it is, if any
+  player *Player
 }
 
-func (t *Turn) Roll() {
-  dice := make([]uint8, t.available)
-  for idx := range t.available {
-    dice[idx] = t.random()
+// turn is a turn at one point, as a value. Its slices are never changed
+// once made, and are clipped so that appending to them copies, which lets
+// turns share them.
+type turn struct {
+  available int
+  dice      Roll
+  rolls     []Roll
+  thrown    []Roll
+  hotDice   int
+  score     uint32
+  farkle    bool
+  banked    bool
+  held      []int
+  // undo is the turn as it was before each keep since the last roll
+  undo []turn
+}
+
+func newTurn(dice int, score uint32) turn {
+  return turn{available: dice, score: score}
+}
+
+// Roll rolls the available dice. At least one scoring die must have been
+// kept since the previous roll.
+func (t *Turn) Roll() error {
+  if t.player != nil {
+    return t.play(t.player.Roll)
+  }
+  if err := t.state.rollable(); err != nil {
+    return err
+  }
+  before := t.state.score
+  next, err := t.state.roll(draw(t.random, t.state.available))
+  if err != nil {
+    return err
+  }
+  t.state = next
+  t.rolled(before)
+  return nil
+}
+
+// rollable returns why the dice can't be rolled, or nil when they can
+func (t turn) rollable() error {
+  switch t.stage() {
+  case Farkled, Banked:
+    return ErrTurnOver
+  case AwaitingKeep:
+    return ErrMustKeepBeforeRoll
+  }
+  return nil
+}
+
+// roll throws the dice, which must be as many as are available
+func (t turn) roll(dice Roll) (turn, error) {
+  if err := t.rollable(); err != nil {
+    return t, err
+  }
+  if len(dice) != t.available {
+    return t, fmt.Errorf("%w: %d dice thrown with %d to roll", ErrInvalidRoll, len(dice), t.available)
   }
-  t.currentRoll = dice
-  if scores := t.currentRoll.Score(); len(scores) == 0 {
+  t.dice = slices.Clip(slices.Clone(dice))
+  t.thrown = append(t.thrown, t.dice)
+  t.thrown = slices.Clip(t.thrown)
+  t.undo = nil
+  t.held = nil
+  if t.dice.Farkle() {
     t.available = 0
     t.score = 0
     t.farkle = true
   }
+  return t, nil
+}
+
+// draw rolls n dice
+func draw(random func() uint8, n int) Roll {
+  dice := make(Roll, n)
+  for idx := range n {
+    dice[idx] = random()
+  }
+  return dice
+}
+
+// rolled logs a roll made from a turn that had scored before
+func (t *Turn) rolled(before uint32) {
+  t.logger.Info("rolled", "dice", t.state.dice, "score", before)
+  if t.state.farkle {
+    t.logger.Info("farkle", "dice", t.state.dice)
+  }
+}
+
+// State returns the stage the turn has reached
+func (t *Turn) State() State {
+  return t.state.stage()
+}
+
+func (t turn) stage() State {
+  switch {
+  case t.farkle:
+    return Farkled
+  case t.banked:
+    return Banked
+  case t.dice != nil && len(t.held) == 0:
+    return AwaitingKeep
+  default:
+    return AwaitingRoll
+  }
+}
+
+// Bank concludes the turn, keeping its score. At least one scoring die
+// must have been kept from the last roll.
+func (t *Turn) Bank() error {
+  if t.player != nil {
+    return t.play(t.player.Bank)
+  }
+  next, err := t.state.bank()
+  if err != nil {
+    return err
+  }
+  t.state = next
+  t.banked()
+  return nil
+}
+
+func (t turn) bank() (turn, error) {
+  switch {
+  case t.farkle, t.banked:
+    return t, ErrTurnOver
+  case t.dice == nil:
+    return t, ErrMustRollFirst
+  case len(t.held) == 0:
+    return t, ErrMustKeepBeforeBank
+  }
+  t.banked = true
+  return t, nil
+}
+
+// banked logs a bank
+func (t *Turn) banked() {
+  t.logger.Info("banked", "score", t.state.score, "available", t.state.available)
 }
 
 func (t *Turn) Farkle() bool {
-  return t.farkle
+  return t.state.farkle
+}
+
+// Dice returns the most recent roll
+func (t *Turn) Dice() Roll {
+  return t.state.dice
+}
+
+// Held returns the indexes of the dice kept from the most recent roll
+func (t *Turn) Held() []int {
+  return t.state.held
+}
+
+// Banked reports whether the turn was concluded by banking
+func (t *Turn) Banked() bool {
+  return t.state.banked
+}
+
+// Available returns the number of dice left to roll
+func (t *Turn) Available() int {
+  return t.state.available
+}
+
+// Rolls returns the scoring dice kept during the turn, one roll per scoring
+func (t *Turn) Rolls() []Roll {
+  return t.state.rolls
+}
+
+// Thrown returns every roll of the dice made during the turn
+func (t *Turn) Thrown() []Roll {
+  return t.state.thrown
+}
+
+// HotDice returns the number of times every die scored and all six
+// were rolled again
+func (t *Turn) HotDice() int {
+  return t.state.hotDice
 }
 
 func (t *Turn) Keep(i ...int) error {
+  if t.player != nil {
+    return t.play(func() error { return t.player.Keep(i...) })
+  }
+  keep := slices.Clone(i)
+  next, err := t.state.keep(i...)
+  if err != nil {
+    return err
+  }
+  before := t.state
+  t.state = next
+  t.kept(keep, before)
+  return nil
+}
+
+func (t turn) keep(i ...int) (turn, error) {
+  switch {
+  case t.farkle, t.banked:
+    return t, ErrTurnOver
+  case t.dice == nil:
+    return t, ErrMustRollFirst
+  }
   kept := len(i)
+  if kept == 0 {
+    return t, fmt.Errorf("%w: no dice given", ErrInvalidKeep)
+  }
   if kept > t.available {
-    return fmt.Errorf("can only keep %d dice", t.available)
+    return t, fmt.Errorf("%w: can only keep %d dice", ErrInvalidKeep, t.available)
+  }
+  // sort a copy, leaving the caller's dice as they were
+  i = slices.Sorted(slices.Values(i))
+  for idx, j := range i {
+    switch {
+    case j < 0 || j >= len(t.dice):
+      return t, fmt.Errorf("%w: no die %d in roll", ErrInvalidKeep, j)
+    case slices.Contains(t.held, j) || (idx > 0 && i[idx-1] == j):
+      return t, fmt.Errorf("%w: die %d already kept", ErrInvalidKeep, j)
+    }
   }
   candidates := make([]*candidate, 0)
-  sort.Ints(i)
-  scorings := t.currentRoll.Score()
-  for _, scoring := range scorings {
+  held := append(t.held, i...)
+  for _, scoring := range t.dice.Scorings() {
     if slices.Equal(i, scoring.Set) {
       roll := make(Roll, len(i))
       for idx, j := range i {
-        roll[idx] = t.currentRoll[j]
-      }
-      t.rolls = append(t.rolls, roll)
-      t.score += scoring.Score
-      for _, c := range candidates {
-        t.rolls = append(t.rolls, c.roll)
-        t.score += c.score
+        roll[idx] = t.dice[j]
       }
-      t.available -= kept
-      if t.available == 0 {
-        t.available = startDice
-      }
-      return nil
+      return t.scored(kept, held, append([]*candidate{{roll: roll, score: scoring.Score}}, candidates...)), nil
     }
     c, truncated := t.checkSubset(scoring, i...)
     if c != nil {
@@ -77,25 +266,80 @@ func (t *Turn) Keep(i ...int) error {
       i = truncated
     }
     if len(i) == 0 {
-      t.available -= kept
-      if t.available == 0 {
-        t.available = startDice
-      }
-      for _, c := range candidates {
-        t.rolls = append(t.rolls, c.roll)
-        t.score += c.score
-      }
-      return nil
+      return t.scored(kept, held, candidates), nil
     }
   }
-  return fmt.Errorf("invalid keep sequence: %v", i)
+  return t, fmt.Errorf("%w: no scoring for dice %v", ErrInvalidKeep, i)
+}
+
+// scored returns the turn after keeping dice that score as the candidates
+func (t turn) scored(kept int, held []int, candidates []*candidate) turn {
+  before := t
+  before.undo = nil
+  rolls := t.rolls
+  for _, c := range candidates {
+    rolls = append(rolls, c.roll)
+    t.score += c.score
+  }
+  t.rolls = slices.Clip(rolls)
+  t.available -= kept
+  if t.available == 0 {
+    t.available = startDice
+    t.hotDice++
+  }
+  t.held = slices.Clip(held)
+  t.undo = slices.Clip(append(t.undo[max(len(t.undo)-maxUndo+1, 0):], before))
+  return t
+}
+
+// kept logs a keep along with the scorings it was made up of
+func (t *Turn) kept(keep []int, before turn) {
+  t.logger.Info("kept", "keep", keep, "scorings", t.state.rolls[len(before.rolls):], "score", t.state.score, "available", t.state.available)
+}
+
+// Undo takes back the most recent keep since the last roll
+func (t *Turn) Undo() error {
+  if t.player != nil {
+    return t.play(t.player.Undo)
+  }
+  next, err := t.state.undone()
+  if err != nil {
+    return err
+  }
+  t.state = next
+  t.undone()
+  return nil
+}
+
+func (t turn) undone() (turn, error) {
+  if len(t.undo) == 0 {
+    return t, fmt.Errorf("%w since the last roll", ErrNothingToUndo)
+  }
+  last := t.undo[len(t.undo)-1]
+  t.undo = slices.Clip(t.undo[:len(t.undo)-1])
+  t.available, t.rolls, t.score, t.hotDice, t.held = last.available, last.rolls, last.score, last.hotDice, last.held
+  return t, nil
+}
+
+// undone logs an undo
+func (t *Turn) undone() {
+  t.logger.Info("undone", "score", t.state.score, "available", t.state.available)
+}
+
+// play takes an action of a player's turn through the player, as long as
+// it is still their turn in progress
+func (t *Turn) play(take func() error) error {
+  if t.player.current != t {
+    return ErrTurnOver
+  }
+  return take()
 }
 
 func (t *Turn) Result() uint32 {
-  return t.score
+  return t.state.score
 }
 
-func (t *Turn) checkSubset(scoring *Scoring, i ...int) (*candidate, []int) {
+func (t turn) checkSubset(scoring Scoring, i ...int) (*candidate, []int) {
   if len(scoring.Set) > len(i) {
     return nil, i
   }
@@ -107,7 +351,7 @@ func (t *Turn) checkSubset(scoring *Scoring, i ...int) (*candidate, []int) {
   roll := make(Roll, len(scoring.Set))
   ret := make([]int, 0, len(i)-len(roll))
   for i, j := range scoring.Set {
-    roll[i] = t.currentRoll[j]
+    roll[i] = t.dice[j]
   }
   for j := range i {
     if !slices.Contains(scoring.Set, i[j]) {
@@ -118,7 +362,7 @@ func (t *Turn) checkSubset(scoring *Scoring, i ...int) (*candidate, []int) {
 }
 
 func NewTurn(random Random, opts ...Opt) *Turn {
-  turn := &Turn{available: startDice, random: random}
+  turn := &Turn{state: newTurn(startDice, 0), random: random, logger: nop}
   for _, opt := range opts {
     opt(turn)
   }
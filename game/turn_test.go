@@ -1,8 +1,10 @@
 package game_test
 
 import (
+  "errors"
   "testing"
 
+  "github.com/ryannatesmith/farkle/farkletest"
   "github.com/ryannatesmith/farkle/game"
   "github.com/google/go-cmp/cmp"
 )
@@ -34,7 +36,7 @@ func TestTurn_Play(t *testing.T) {
     {
       name: "start by continuing roll",
       play: func() *game.Turn {
-        turn := game.NewTurn(random([]uint8{5, 4}), game.WithStart(2, 1000))
+        turn := game.NewTurn(farkletest.NewDice(5, 4).Roll, game.WithStart(2, 1000))
         turn.Roll()
         if err := turn.Keep(0); err != nil {
           t.Fatal(err)
@@ -46,7 +48,7 @@ func TestTurn_Play(t *testing.T) {
     {
       name: "one roll keep four",
       play: func() *game.Turn {
-        turn := game.NewTurn(random([]uint8{1, 1, 1, 5, 4, 2}))
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll)
         turn.Roll()
         if err := turn.Keep(0, 1, 2, 3); err != nil {
           t.Fatal(err)
@@ -59,7 +61,7 @@ func TestTurn_Play(t *testing.T) {
     {
       name: "complicated roll",
       play: func() *game.Turn {
-        turn := game.NewTurn(random([]uint8{1, 3, 4, 1, 1, 1, 5, 6, 5}))
+        turn := game.NewTurn(farkletest.NewDice(1, 3, 4, 1, 1, 1, 5, 6, 5).Roll)
         turn.Roll()
         if err := turn.Keep(0, 3, 4, 5); err != nil {
           t.Fatal(err)
@@ -80,7 +82,7 @@ func TestTurn_Play(t *testing.T) {
     {
       name: "re-roll all dice and add scores",
       play: func() *game.Turn {
-        turn := game.NewTurn(random([]uint8{1, 2, 3, 4, 5, 6, 1, 1, 1, 5, 4, 3}))
+        turn := game.NewTurn(farkletest.NewDice(1, 2, 3, 4, 5, 6, 1, 1, 1, 5, 4, 3).Roll)
         turn.Roll()
         if err := turn.Keep(0, 1, 2, 3, 4, 5); err != nil {
           t.Fatal(err)
@@ -97,7 +99,7 @@ func TestTurn_Play(t *testing.T) {
     {
       name: "farkle",
       play: func() *game.Turn {
-        turn := game.NewTurn(random([]uint8{3, 3, 3, 5, 4, 2, 4, 3}))
+        turn := game.NewTurn(farkletest.NewDice(3, 3, 3, 5, 4, 2, 4, 3).Roll)
         turn.Roll()
         if err := turn.Keep(0, 1, 2, 3); err != nil {
           t.Fatal(err)
@@ -155,7 +157,7 @@ func TestTurn_PlayError(t *testing.T) {
     {
       name: "non-scoring di kept",
       play: func(t *testing.T) {
-        turn := game.NewTurn(random([]uint8{1, 1, 1, 5, 4, 3}))
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 3).Roll)
         turn.Roll()
         if err := turn.Keep(1, 2, 3, 4, 5); err == nil {
           t.Error("should have got error")
@@ -168,7 +170,7 @@ func TestTurn_PlayError(t *testing.T) {
     {
       name: "non-scoring di kept with scoring dice",
       play: func(t *testing.T) {
-        turn := game.NewTurn(random([]uint8{1, 1, 1, 5, 4, 3}))
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 3).Roll)
         turn.Roll()
         if err := turn.Keep(0, 4); err == nil {
           t.Error("should have got error")
@@ -178,6 +180,48 @@ func TestTurn_PlayError(t *testing.T) {
         }
       },
     },
+    {
+      name: "same die kept twice",
+      play: func(t *testing.T) {
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 3).Roll)
+        turn.Roll()
+        if err := turn.Keep(0); err != nil {
+          t.Fatal(err)
+        }
+        if err := turn.Keep(0); !errors.Is(err, game.ErrInvalidKeep) {
+          t.Error("unexpected error", err)
+        }
+        if err := turn.Keep(3, 3); !errors.Is(err, game.ErrInvalidKeep) {
+          t.Error("unexpected error", err)
+        }
+        if turn.Result() != 100 {
+          t.Error("turn result should be 100")
+        }
+      },
+    },
+    {
+      name: "re-roll without keeping",
+      play: func(t *testing.T) {
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 3).Roll)
+        turn.Roll()
+        if err := turn.Roll(); !errors.Is(err, game.ErrMustKeepBeforeRoll) {
+          t.Error("unexpected error", err)
+        }
+        if got := turn.State(); got != game.AwaitingKeep {
+          t.Errorf("state: +want -got\n\t+%v\n\t-%v", game.AwaitingKeep, got)
+        }
+      },
+    },
+    {
+      name: "bank without keeping",
+      play: func(t *testing.T) {
+        turn := game.NewTurn(farkletest.NewDice(1, 1, 1, 5, 4, 3).Roll, game.WithStart(6, 500))
+        turn.Roll()
+        if err := turn.Bank(); !errors.Is(err, game.ErrMustKeepBeforeBank) {
+          t.Error("unexpected error", err)
+        }
+      },
+    },
   } {
     t.Run(c.name, func(t *testing.T) {
       t.Parallel()
@@ -185,11 +229,3 @@ func TestTurn_PlayError(t *testing.T) {
     })
   }
 }
-
-func random(dice []uint8) func() uint8 {
-  roll := -1
-  return func() uint8 {
-    roll++
-    return dice[roll]
-  }
-}
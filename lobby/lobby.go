@@ -0,0 +1,348 @@
+// Package lobby gathers players before a game. A host configures the
+// rules, players join with an invite code and ready up, and once everyone
+// is ready the lobby hands them to a new game and starts it. A Queue
+// matches players of similar rating into lobbies automatically.
+package lobby
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	defaultMinSeats = 2
+	defaultMaxSeats = 6
+	codeLength      = 6
+	// codeAlphabet leaves out letters and digits that are easily confused
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var (
+	ErrUnknownCode   = errors.New("no lobby with that invite code")
+	ErrFull          = errors.New("lobby is full")
+	ErrNotHost       = errors.New("only the host can do that")
+	ErrNotMember     = errors.New("not in the lobby")
+	ErrAlreadyMember = errors.New("already in the lobby")
+	ErrNotReady      = errors.New("not everyone is ready")
+	ErrTooFewPlayers = errors.New("too few players")
+	ErrStarted       = errors.New("game has already started")
+	ErrInvalidRules  = errors.New("invalid rules")
+)
+
+// Rules are the settings the host chooses for the game
+type Rules struct {
+	// Target is the score that triggers the final round, 10000 when zero
+	Target uint32
+	// Opening is the score a player must bank in one turn to get on the board
+	Opening uint32
+	NoUndo  bool
+	// MinSeats and MaxSeats bound the number of players, two to six when zero
+	MinSeats int
+	MaxSeats int
+}
+
+func (r Rules) seats() (int, int) {
+	low, high := r.MinSeats, r.MaxSeats
+	if low == 0 {
+		low = defaultMinSeats
+	}
+	if high == 0 {
+		high = defaultMaxSeats
+	}
+	return low, high
+}
+
+func (r Rules) validate() error {
+	low, high := r.seats()
+	if low < 1 || high < low {
+		return fmt.Errorf("%w: between %d and %d seats", ErrInvalidRules, low, high)
+	}
+	return nil
+}
+
+// Member is a player waiting in a lobby
+type Member struct {
+	ID    string
+	Name  string
+	Ready bool
+	opts  []game.PlayerOpt
+}
+
+type Lobby struct {
+	mu       sync.Mutex
+	code     string
+	host     string
+	rules    Rules
+	members  []*Member
+	gameOpts []game.GameOpt
+	game     *game.Game
+	// closed is called once the game starts or everyone has left
+	closed func(*Lobby)
+}
+
+// Code returns the invite code others join with
+func (l *Lobby) Code() string {
+	return l.code
+}
+
+// Host returns the ID of the member who configures the lobby
+func (l *Lobby) Host() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.host
+}
+
+func (l *Lobby) Rules() Rules {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rules
+}
+
+// Members returns a copy of everyone in the lobby, in the order they joined
+func (l *Lobby) Members() []Member {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ret := make([]Member, len(l.members))
+	for i, member := range l.members {
+		ret[i] = *member
+	}
+	return ret
+}
+
+// Game returns the game the lobby handed off to, or nil before it starts
+func (l *Lobby) Game() *game.Game {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.game
+}
+
+// Configure changes the rules. Only the host can, and every member must
+// ready up again.
+func (l *Lobby) Configure(by string, rules Rules) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(by); err != nil {
+		return err
+	}
+	if by != l.host {
+		return ErrNotHost
+	}
+	if err := rules.validate(); err != nil {
+		return err
+	}
+	if _, high := rules.seats(); len(l.members) > high {
+		return fmt.Errorf("%w: %d players have already joined", ErrInvalidRules, len(l.members))
+	}
+	l.rules = rules
+	for _, member := range l.members {
+		member.Ready = false
+	}
+	return nil
+}
+
+// Join adds a player to the lobby. The options are passed on to
+// game.Join, for teams and handicaps.
+func (l *Lobby) Join(id, name string, opts ...game.PlayerOpt) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.game != nil {
+		return ErrStarted
+	}
+	if l.member(id) != nil {
+		return ErrAlreadyMember
+	}
+	if _, high := l.rules.seats(); len(l.members) >= high {
+		return ErrFull
+	}
+	l.members = append(l.members, &Member{ID: id, Name: name, opts: opts})
+	if l.host == "" {
+		l.host = id
+	}
+	return nil
+}
+
+// Leave takes a player out of the lobby. When the host leaves, the next
+// member to have joined becomes host.
+func (l *Lobby) Leave(id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(id); err != nil {
+		return err
+	}
+	l.members = slices.DeleteFunc(l.members, func(m *Member) bool { return m.ID == id })
+	if l.host == id {
+		l.host = ""
+		if len(l.members) > 0 {
+			l.host = l.members[0].ID
+		}
+	}
+	if len(l.members) == 0 && l.closed != nil {
+		l.closed(l)
+	}
+	return nil
+}
+
+// Ready marks whether a member is ready to play. Once every member is
+// ready, and there are enough of them, the game starts and is returned.
+func (l *Lobby) Ready(id string, ready bool) (*game.Game, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(id); err != nil {
+		return nil, err
+	}
+	l.member(id).Ready = ready
+	if low, _ := l.rules.seats(); len(l.members) < low || slices.ContainsFunc(l.members, func(m *Member) bool { return !m.Ready }) {
+		return nil, nil
+	}
+	return l.start()
+}
+
+// Start starts the game at the host's request, once everyone is ready
+func (l *Lobby) Start(by string) (*game.Game, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.check(by); err != nil {
+		return nil, err
+	}
+	if by != l.host {
+		return nil, ErrNotHost
+	}
+	if low, _ := l.rules.seats(); len(l.members) < low {
+		return nil, fmt.Errorf("%w: need %d", ErrTooFewPlayers, low)
+	}
+	if slices.ContainsFunc(l.members, func(m *Member) bool { return !m.Ready }) {
+		return nil, ErrNotReady
+	}
+	return l.start()
+}
+
+// start hands the members to a new game and starts it
+func (l *Lobby) start() (*game.Game, error) {
+	opts := []game.GameOpt{game.WithOpening(l.rules.Opening)}
+	if l.rules.Target > 0 {
+		opts = append(opts, game.WithTarget(l.rules.Target))
+	}
+	if l.rules.NoUndo {
+		opts = append(opts, game.WithoutUndo())
+	}
+	g := game.NewGame(append(opts, l.gameOpts...)...)
+	for _, member := range l.members {
+		g.Join(member.Name, append([]game.PlayerOpt{game.WithID(member.ID)}, member.opts...)...)
+	}
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
+	l.game = g
+	if l.closed != nil {
+		l.closed(l)
+	}
+	return g, nil
+}
+
+// check returns an error unless the game is yet to start and id is a member
+func (l *Lobby) check(id string) error {
+	if l.game != nil {
+		return ErrStarted
+	}
+	if l.member(id) == nil {
+		return ErrNotMember
+	}
+	return nil
+}
+
+func (l *Lobby) member(id string) *Member {
+	for _, member := range l.members {
+		if member.ID == id {
+			return member
+		}
+	}
+	return nil
+}
+
+type Opt func(*Lobbies)
+
+// WithGameOpts sets options every game is created with, after those of
+// the rules
+func WithGameOpts(opts ...game.GameOpt) Opt {
+	return func(l *Lobbies) {
+		l.gameOpts = opts
+	}
+}
+
+// Lobbies holds every open lobby by invite code. Lobbies close once their
+// game starts.
+type Lobbies struct {
+	mu       sync.Mutex
+	gameOpts []game.GameOpt
+	open     map[string]*Lobby
+}
+
+// Create opens a lobby with the player as host
+func (ls *Lobbies) Create(host, name string, rules Rules, opts ...game.PlayerOpt) (*Lobby, error) {
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	code := newCode()
+	for ls.open[code] != nil {
+		code = newCode()
+	}
+	l := &Lobby{code: code, rules: rules, gameOpts: ls.gameOpts, closed: ls.close}
+	if err := l.Join(host, name, opts...); err != nil {
+		return nil, err
+	}
+	ls.open[code] = l
+	return l, nil
+}
+
+// Find returns the open lobby with the invite code
+func (ls *Lobbies) Find(code string) (*Lobby, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, ok := ls.open[code]
+	if !ok {
+		return nil, ErrUnknownCode
+	}
+	return l, nil
+}
+
+// Open returns the invite codes of every open lobby
+func (ls *Lobbies) Open() []string {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ret := make([]string, 0, len(ls.open))
+	for code := range ls.open {
+		ret = append(ret, code)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func (ls *Lobbies) close(l *Lobby) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	delete(ls.open, l.code)
+}
+
+func New(opts ...Opt) *Lobbies {
+	ls := &Lobbies{open: make(map[string]*Lobby)}
+	for _, opt := range opts {
+		opt(ls)
+	}
+	return ls
+}
+
+func newCode() string {
+	b := make([]byte, codeLength)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b)
+}
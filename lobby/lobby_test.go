@@ -0,0 +1,139 @@
+package lobby_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/lobby"
+)
+
+func TestLobby(t *testing.T) {
+	t.Parallel()
+	lobbies := lobby.New()
+	l, err := lobbies.Create("a", "alice", lobby.Rules{Target: 2_000, MaxSeats: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := lobbies.Find(l.Code())
+	if err != nil || found != l {
+		t.Fatalf("could not find lobby %q: %v", l.Code(), err)
+	}
+	for _, c := range []struct {
+		name string
+		do   func() error
+		want error
+	}{
+		{name: "join", do: func() error { return l.Join("b", "bob") }},
+		{name: "join twice", do: func() error { return l.Join("b", "bob") }, want: lobby.ErrAlreadyMember},
+		{name: "join with a handicap", do: func() error { return l.Join("c", "carol", game.WithHandicap(game.Handicap{Start: 500})) }},
+		{name: "join when full", do: func() error { return l.Join("d", "dan") }, want: lobby.ErrFull},
+		{name: "configure as guest", do: func() error { return l.Configure("b", lobby.Rules{}) }, want: lobby.ErrNotHost},
+		{name: "too few seats", do: func() error { return l.Configure("a", lobby.Rules{MaxSeats: 2}) }, want: lobby.ErrInvalidRules},
+		{name: "start before ready", do: func() error { _, err := l.Start("a"); return err }, want: lobby.ErrNotReady},
+		{name: "ready", do: func() error { _, err := l.Ready("a", true); return err }},
+		{name: "configure", do: func() error { return l.Configure("a", lobby.Rules{Target: 3_000, MaxSeats: 3}) }},
+		{name: "leave", do: func() error { return l.Leave("c") }},
+		{name: "ready when not a member", do: func() error { _, err := l.Ready("c", true); return err }, want: lobby.ErrNotMember},
+	} {
+		if err := c.do(); !errors.Is(err, c.want) {
+			t.Errorf("%s: +want -got\n\t+%v\n\t-%v", c.name, c.want, err)
+		}
+	}
+	if !cmp.Equal([]bool{false, false}, []bool{l.Members()[0].Ready, l.Members()[1].Ready}) {
+		t.Error("configuring should reset readiness")
+	}
+	for _, id := range []string{"a", "b"} {
+		g, err := l.Ready(id, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (g != nil) != (id == "b") {
+			t.Fatalf("game handed off too soon or not at all after %s readied", id)
+		}
+	}
+	g := l.Game()
+	if g.State() != game.AwaitingRoll || g.Target() != 3_000 || len(g.Players()) != 2 || g.Current().ID() != "a" {
+		t.Errorf("unexpected game: state %v, target %d, %d players", g.State(), g.Target(), len(g.Players()))
+	}
+	if _, err := lobbies.Find(l.Code()); !errors.Is(err, lobby.ErrUnknownCode) {
+		t.Errorf("lobby still open once started: %v", err)
+	}
+	if err := l.Join("d", "dan"); !errors.Is(err, lobby.ErrStarted) {
+		t.Errorf("joined after start: %v", err)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ratings := map[string]float64{"a": 1_500, "b": 1_520, "c": 1_900, "d": 2_000, "e": 2_500, "f": 2_900}
+	lobbies := lobby.New()
+	q, err := lobby.NewQueue(lobbies, func(id string) float64 { return ratings[id] }, lobby.WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"e", "c", "a", "d", "b"} {
+		if err := q.Enqueue(id, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Enqueue("a", "a"); !errors.Is(err, lobby.ErrQueued) {
+		t.Errorf("queued twice: %v", err)
+	}
+	matched, err := q.Match()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([][]string{{"a", "b"}, {"c", "d"}}, ids(matched)); diff != "" {
+		t.Errorf("matches: +want -got\n%s", diff)
+	}
+	if q.Waiting() != 1 {
+		t.Errorf("waiting: +want -got\n\t+1\n\t-%d", q.Waiting())
+	}
+	if err := q.Enqueue("f", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if matched, _ := q.Match(); len(matched) != 0 {
+		t.Errorf("matched ratings 400 apart without waiting: %v", ids(matched))
+	}
+	now = now.Add(3 * time.Minute)
+	matched, err = q.Match()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([][]string{{"e", "f"}}, ids(matched)); diff != "" {
+		t.Errorf("matches after waiting: +want -got\n%s", diff)
+	}
+	if got := len(lobbies.Open()); got != 3 {
+		t.Errorf("open lobbies: +want -got\n\t+3\n\t-%d", got)
+	}
+}
+
+func TestNewQueue(t *testing.T) {
+	t.Parallel()
+	rate := func(string) float64 { return 1_500 }
+	for _, opts := range [][]lobby.QueueOpt{
+		{lobby.WithSeats(0)},
+		{lobby.WithSeats(3), lobby.WithRules(lobby.Rules{MaxSeats: 2})},
+		{lobby.WithSeats(2), lobby.WithRules(lobby.Rules{MinSeats: 3})},
+	} {
+		if _, err := lobby.NewQueue(lobby.New(), rate, opts...); !errors.Is(err, lobby.ErrInvalidRules) {
+			t.Errorf("error: +want -got\n\t+%v\n\t-%v", lobby.ErrInvalidRules, err)
+		}
+	}
+}
+
+func ids(lobbies []*lobby.Lobby) [][]string {
+	var ret [][]string
+	for _, l := range lobbies {
+		var group []string
+		for _, member := range l.Members() {
+			group = append(group, member.ID)
+		}
+		ret = append(ret, group)
+	}
+	return ret
+}
@@ -0,0 +1,206 @@
+package lobby
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rating"
+)
+
+const (
+	defaultSpread   = 200
+	defaultWidening = 100
+)
+
+var ErrQueued = errors.New("already queued")
+
+type QueueOpt func(*Queue)
+
+// WithSeats sets the number of players matched into each lobby, two by
+// default
+func WithSeats(seats int) QueueOpt {
+	return func(q *Queue) {
+		q.seats = seats
+	}
+}
+
+// WithSpread sets the widest gap in rating between players matched
+// together, 200 points by default
+func WithSpread(points float64) QueueOpt {
+	return func(q *Queue) {
+		q.spread = points
+	}
+}
+
+// WithWidening sets how many points the spread grows by for each minute
+// the longest waiting player has queued, 100 by default
+func WithWidening(points float64) QueueOpt {
+	return func(q *Queue) {
+		q.widening = points
+	}
+}
+
+// WithRules sets the rules of the lobbies matches are made in
+func WithRules(rules Rules) QueueOpt {
+	return func(q *Queue) {
+		q.rules = rules
+	}
+}
+
+// WithClock sets the source of the current time, for tests
+func WithClock(now func() time.Time) QueueOpt {
+	return func(q *Queue) {
+		q.now = now
+	}
+}
+
+// Rated looks up players' ratings in a pool, for matching by
+func Rated(ratings *rating.Ratings, pool rating.Pool) func(id string) float64 {
+	return func(id string) float64 {
+		return ratings.Rating(pool, id).Value
+	}
+}
+
+// waiting is a player in the queue
+type waiting struct {
+	id     string
+	name   string
+	opts   []game.PlayerOpt
+	rating float64
+	since  time.Time
+}
+
+// Queue matches waiting players of similar rating into lobbies, where
+// they still ready up before their game starts
+type Queue struct {
+	mu       sync.Mutex
+	lobbies  *Lobbies
+	rate     func(id string) float64
+	seats    int
+	spread   float64
+	widening float64
+	rules    Rules
+	now      func() time.Time
+	waiting  []*waiting
+}
+
+// Enqueue adds a player to the queue. The options are passed on to
+// game.Join.
+func (q *Queue) Enqueue(id, name string, opts ...game.PlayerOpt) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if slices.ContainsFunc(q.waiting, func(w *waiting) bool { return w.id == id }) {
+		return ErrQueued
+	}
+	q.waiting = append(q.waiting, &waiting{id: id, name: name, opts: opts, rating: q.rate(id), since: q.now()})
+	return nil
+}
+
+// Dequeue takes a player out of the queue, reporting whether they were in it
+func (q *Queue) Dequeue(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.waiting)
+	q.waiting = slices.DeleteFunc(q.waiting, func(w *waiting) bool { return w.id == id })
+	return len(q.waiting) < n
+}
+
+// Waiting returns the number of players in the queue
+func (q *Queue) Waiting() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.waiting)
+}
+
+// Match groups waiting players into lobbies, closest ratings first. A
+// group is matched when its ratings are within the spread, which widens
+// the longer its members have waited.
+func (q *Queue) Match() ([]*Lobby, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	slices.SortStableFunc(q.waiting, func(a, b *waiting) int {
+		switch {
+		case a.rating < b.rating:
+			return -1
+		case a.rating > b.rating:
+			return 1
+		default:
+			return a.since.Compare(b.since)
+		}
+	})
+	now := q.now()
+	var (
+		ret  []*Lobby
+		left []*waiting
+	)
+	for i := 0; i < len(q.waiting); {
+		if i+q.seats > len(q.waiting) {
+			left = append(left, q.waiting[i:]...)
+			break
+		}
+		group := q.waiting[i : i+q.seats]
+		if !q.close(group, now) {
+			left = append(left, q.waiting[i])
+			i++
+			continue
+		}
+		l, err := q.open(group)
+		if err != nil {
+			// players already placed leave the queue, the rest stay in it
+			q.waiting = append(left, q.waiting[i:]...)
+			return ret, err
+		}
+		ret = append(ret, l)
+		i += q.seats
+	}
+	q.waiting = left
+	return ret, nil
+}
+
+// close reports whether a group sorted by rating is within the spread
+func (q *Queue) close(group []*waiting, now time.Time) bool {
+	var longest time.Duration
+	for _, w := range group {
+		longest = max(longest, now.Sub(w.since))
+	}
+	return group[len(group)-1].rating-group[0].rating <= q.spread+q.widening*longest.Minutes()
+}
+
+// open creates a lobby for a group, hosted by its longest waiting player
+func (q *Queue) open(group []*waiting) (*Lobby, error) {
+	host := slices.MinFunc(group, func(a, b *waiting) int { return a.since.Compare(b.since) })
+	l, err := q.lobbies.Create(host.id, host.name, q.rules, host.opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range group {
+		if w == host {
+			continue
+		}
+		if err := l.Join(w.id, w.name, w.opts...); err != nil {
+			q.lobbies.close(l)
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// NewQueue matches players by the ratings rate gives them, into lobbies
+// opened in lobbies. The number of seats must be allowed by the rules.
+func NewQueue(lobbies *Lobbies, rate func(id string) float64, opts ...QueueOpt) (*Queue, error) {
+	q := &Queue{lobbies: lobbies, rate: rate, seats: defaultMinSeats, spread: defaultSpread, widening: defaultWidening, now: time.Now}
+	for _, opt := range opts {
+		opt(q)
+	}
+	if err := q.rules.validate(); err != nil {
+		return nil, err
+	}
+	if low, high := q.rules.seats(); q.seats < low || q.seats > high {
+		return nil, fmt.Errorf("%w: %d seats, not between %d and %d", ErrInvalidRules, q.seats, low, high)
+	}
+	return q, nil
+}
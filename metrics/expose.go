@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// counter is a value that only goes up, kept per set of label values
+type counter struct {
+	mu     sync.Mutex
+	name   string
+	help   string
+	labels []string
+	values map[string]float64
+}
+
+func newCounter(name, help string, labels ...string) *counter {
+	c := &counter{name: name, help: help, labels: labels, values: make(map[string]float64)}
+	if len(labels) == 0 {
+		c.values[""] = 0
+	}
+	return c
+}
+
+func (c *counter) add(v float64, values ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[strings.Join(values, "\xff")] += v
+}
+
+func (c *counter) write(w io.Writer, kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", c.name, c.help, c.name, kind)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, labels(c.labels, key), format(c.values[key]))
+	}
+}
+
+// histogram counts observations into buckets of at most each bound, per
+// set of label values
+type histogram struct {
+	mu     sync.Mutex
+	name   string
+	help   string
+	bounds []float64
+	labels []string
+	series map[string]*series
+}
+
+type series struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func newHistogram(name, help string, bounds []float64, labels ...string) *histogram {
+	h := &histogram{name: name, help: help, bounds: bounds, labels: labels, series: make(map[string]*series)}
+	if len(labels) == 0 {
+		h.series[""] = &series{buckets: make([]uint64, len(bounds))}
+	}
+	return h
+}
+
+func (h *histogram) observe(v float64, values ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.Join(values, "\xff")
+	s, ok := h.series[key]
+	if !ok {
+		s = &series{buckets: make([]uint64, len(h.bounds))}
+		h.series[key] = s
+	}
+	for i, bound := range h.bounds {
+		if v <= bound {
+			s.buckets[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		for i, bound := range h.bounds {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(append(slices.Clone(h.labels), "le"), key+sep(key)+format(bound)), s.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(append(slices.Clone(h.labels), "le"), key+sep(key)+"+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels(h.labels, key), format(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels(h.labels, key), s.count)
+	}
+}
+
+// sep separates a bucket's bound from the other label values, if any
+func sep(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "\xff"
+}
+
+var escape = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats label names with the values joined in key
+func labels(names []string, key string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	values := strings.Split(key, "\xff")
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + escape.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func format(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
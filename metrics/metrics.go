@@ -0,0 +1,176 @@
+// Package metrics counts what happens in games and in the server for
+// scraping by Prometheus. Games feed it through a Listener each, handlers
+// are timed by Instrument and Handler serves the text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+var (
+	// turnBuckets spans a farkle up to a long hot streak
+	turnBuckets = []float64{0, 100, 250, 500, 750, 1_000, 1_500, 2_000, 3_000, 5_000}
+	// latencyBuckets are in seconds
+	latencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+)
+
+type Opt func(*Metrics)
+
+// WithIdle sets how long a game can go without an event before it no
+// longer counts as active, an hour by default
+func WithIdle(idle time.Duration) Opt {
+	return func(m *Metrics) {
+		m.idle = idle
+	}
+}
+
+// WithClock sets the source of the current time, for tests
+func WithClock(now func() time.Time) Opt {
+	return func(m *Metrics) {
+		m.now = now
+	}
+}
+
+type Metrics struct {
+	started  *counter
+	finished *counter
+	actions  *counter
+	farkles  *counter
+	banked   *counter
+	turns    *histogram
+	requests *histogram
+	idle     time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	// live holds when each game still being played last had an event
+	live map[*listener]time.Time
+}
+
+// listener counts the events of one game
+type listener struct {
+	m       *Metrics
+	playing bool
+	// pending is the score of a bank that can still be undone, counted
+	// once it no longer can be
+	pending *uint32
+}
+
+// Listener returns a listener that counts the events of one game. Give
+// each game its own with game.WithListener.
+func (m *Metrics) Listener() func(game.Event) {
+	l := &listener{m: m}
+	return l.listen
+}
+
+func (l *listener) listen(event game.Event) {
+	m := l.m
+	m.actions.add(1, string(event.Type))
+	switch event.Type {
+	case game.EventStarted:
+		m.started.add(1)
+		l.playing = true
+	case game.EventEnded:
+		m.finished.add(1)
+		l.playing = false
+	case game.EventFarkle:
+		m.farkles.add(1)
+		m.turns.observe(0)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// a bank can be undone until the next turn's first roll or a skip, and
+	// never once the game is over
+	switch event.Type {
+	case game.EventBanked:
+		score := event.Score
+		l.pending = &score
+	case game.EventUndone:
+		l.pending = nil
+	case game.EventRolled, game.EventSkipped, game.EventEnded:
+		l.bank()
+	}
+	if l.playing {
+		m.live[l] = m.now()
+	} else {
+		delete(m.live, l)
+	}
+}
+
+// bank counts the pending bank, if any, now that it can't be undone
+func (l *listener) bank() {
+	if l.pending == nil {
+		return
+	}
+	l.m.banked.add(float64(*l.pending))
+	l.m.turns.observe(float64(*l.pending))
+	l.pending = nil
+}
+
+// active returns the number of games that have had an event recently
+// without finishing, forgetting the rest as abandoned
+func (m *Metrics) active() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for l, last := range m.live {
+		if m.now().Sub(last) > m.idle {
+			l.bank()
+			delete(m.live, l)
+		}
+	}
+	return len(m.live)
+}
+
+// Instrument times every request to the handler under the endpoint's name
+func (m *Metrics) Instrument(endpoint string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			m.requests.observe(time.Since(start).Seconds(), endpoint)
+		}()
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Handler serves every metric in the Prometheus text format, for mounting
+// at /metrics
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		m.started.write(bw, "counter")
+		m.finished.write(bw, "counter")
+		active := newCounter("farkle_games_active", "Games being played, with an event recently.")
+		active.add(float64(m.active()))
+		active.write(bw, "gauge")
+		m.actions.write(bw, "counter")
+		m.farkles.write(bw, "counter")
+		m.banked.write(bw, "counter")
+		m.turns.write(bw)
+		m.requests.write(bw)
+		_ = bw.Flush()
+	})
+}
+
+func New(opts ...Opt) *Metrics {
+	m := &Metrics{
+		started:  newCounter("farkle_games_started_total", "Games started."),
+		finished: newCounter("farkle_games_finished_total", "Games played to the end."),
+		actions:  newCounter("farkle_actions_total", "Game events by type.", "type"),
+		farkles:  newCounter("farkle_farkles_total", "Turns ended by a roll that did not score."),
+		banked:   newCounter("farkle_banked_points_total", "Points banked across every game."),
+		turns:    newHistogram("farkle_turn_score", "Score of each finished turn, zero for a farkle.", turnBuckets),
+		requests: newHistogram("farkle_request_duration_seconds", "Time taken to serve requests by endpoint.", latencyBuckets, "endpoint"),
+		idle:     time.Hour,
+		now:      time.Now,
+		live:     make(map[*listener]time.Time),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
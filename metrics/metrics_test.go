@@ -0,0 +1,130 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/metrics"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	m := metrics.New()
+	g := game.NewGame(game.WithRandom(game.NewSeededRandom(1)), game.WithTarget(2_000), game.WithListener(m.Listener()))
+	g.Join("one")
+	g.Join("two")
+	if err := strategy.Play(g, strategy.Optimal(), strategy.Optimal()); err != nil {
+		t.Fatal(err)
+	}
+	unfinished := game.NewGame(game.WithListener(m.Listener()))
+	unfinished.Join("three")
+	if err := unfinished.Start(); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/ping", m.Instrument("ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	if _, err := http.Get(server.URL + "/ping"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(body)
+	for _, want := range []string{
+		"farkle_games_started_total 2\n",
+		"farkle_games_finished_total 1\n",
+		"farkle_games_active 1\n",
+		"# TYPE farkle_games_active gauge\n",
+		`farkle_actions_total{type="rolled"} `,
+		`farkle_turn_score_bucket{le="+Inf"} `,
+		`farkle_request_duration_seconds_count{endpoint="ping"} 1` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scrape missing %q:\n%s", want, text)
+		}
+	}
+	var banked, turns int
+	for _, player := range g.Players() {
+		banked += int(player.Score())
+		turns += len(player.Turns())
+	}
+	for _, want := range []string{
+		"farkle_banked_points_total " + strconv.Itoa(banked) + "\n",
+		"farkle_turn_score_count " + strconv.Itoa(turns) + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scrape missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestMetrics_Undo(t *testing.T) {
+	t.Parallel()
+	m := metrics.New()
+	h := farkletest.New(t, game.WithListener(m.Listener()))
+	h.Start("ann", "bob")
+	h.Play("roll 1 1 1 5 4 2; keep 0 1 2 3; bank; undo; roll 5 3; keep 0")
+	h.Play("bank; reject")
+	// the bank could still be undone
+	if text := scrape(m); !strings.Contains(text, "farkle_banked_points_total 0\n") {
+		t.Errorf("counted a bank before it was final:\n%s", text)
+	}
+	h.Play("roll 2 3 4 6 4 3")
+	text := scrape(m)
+	for _, want := range []string{
+		"farkle_banked_points_total 400\n",
+		"farkle_turn_score_count 2\n",
+		`farkle_turn_score_bucket{le="250"} 1` + "\n",
+		`farkle_turn_score_bucket{le="500"} 2` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scrape missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestMetrics_Active(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := metrics.New(metrics.WithIdle(time.Minute), metrics.WithClock(func() time.Time { return now }))
+	h := farkletest.New(t, game.WithListener(m.Listener()))
+	h.Start("ann", "bob")
+	h.Play("roll 1 1 1 5 4 2; keep 0 1 2 3; bank")
+	if text := scrape(m); !strings.Contains(text, "farkle_games_active 1\n") {
+		t.Errorf("game in play not active:\n%s", text)
+	}
+	now = now.Add(2 * time.Minute)
+	text := scrape(m)
+	for _, want := range []string{
+		"farkle_games_active 0\n",
+		// an abandoned game's last bank can no longer be undone
+		"farkle_banked_points_total 350\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scrape missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func scrape(m *metrics.Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
@@ -0,0 +1,135 @@
+// Package notation reads and writes Farkle Game Notation, a compact text
+// record of a game that can be pasted into a bug report and replayed.
+//
+//	[Game "g1"]
+//	[Target "10000"]
+//	[Seed "42"]
+//	[Player "a" "Alice"]
+//	[Player "b" "Bob"]
+//
+//	1. a R111542 K0123 B
+//	2. b A R15 K01 R236243 F
+//	3. a X R223344 K012345 R155236 K012 U K0 B ; thought better of it
+//
+// Each line after the tags is one turn: the player's ID followed by their
+// actions. [Seed] gives the seed of the dice rolled after the record ends,
+// and [Undo "off"] that keeps and banks can't be taken back. A [Result]
+// tag names the winner and their score once the game is over, and in a
+// team game a [Team] tag follows the players for each, naming their team.
+// [Opening] gives the score a player must bank in one turn to get on the
+// board, and a [Handicap] tag gives a player's starting score, opening
+// reduction and target reduction. A is accept, X reject, R a roll of the
+// given dice, K a keep of the given dice indexes, B bank, U undo and S
+// skip. F marks a farkle and, like the move numbers, the result and
+// anything after a semicolon, is only for the reader.
+package notation
+
+import (
+	"github.com/ryannatesmith/farkle/game"
+)
+
+type Player struct {
+	ID       string
+	Name     string
+	Team     string
+	Handicap game.Handicap
+}
+
+// Record is everything the notation holds about a game
+type Record struct {
+	ID      string
+	Target  uint32
+	Opening uint32
+	Seed    *uint64
+	NoUndo  bool
+	Players []Player
+	Winner  string
+	Score   uint32
+	// Actions are the events of the game after it started, excluding the
+	// ones that follow from others, such as farkles
+	Actions []game.Event
+}
+
+type Opt func(*Record)
+
+// WithSeed records the seed of the game's dice, which the events don't
+// hold
+func WithSeed(seed uint64) Opt {
+	return func(r *Record) {
+		r.Seed = &seed
+	}
+}
+
+// FromEvents builds a record from a game's events
+func FromEvents(id string, events []game.Event, opts ...Opt) *Record {
+	r := &Record{ID: id}
+	for _, opt := range opts {
+		opt(r)
+	}
+	for _, event := range events {
+		switch event.Type {
+		case game.EventJoined:
+			player := Player{ID: event.Player, Name: event.Name, Team: event.Team}
+			if event.Handicap != nil {
+				player.Handicap = *event.Handicap
+			}
+			r.Players = append(r.Players, player)
+		case game.EventStarted:
+			r.Target, r.Opening, r.NoUndo = event.Score, event.Opening, event.NoUndo
+		case game.EventEnded:
+			r.Winner, r.Score = event.Player, event.Score
+		case game.EventFarkle:
+		default:
+			r.Actions = append(r.Actions, game.Event{
+				Type:   event.Type,
+				Player: event.Player,
+				Dice:   event.Dice,
+				Keep:   event.Keep,
+			})
+		}
+	}
+	return r
+}
+
+// Events returns the events needed to replay the game
+func (r *Record) Events() []game.Event {
+	events := make([]game.Event, 0, len(r.Players)+len(r.Actions)+1)
+	for _, player := range r.Players {
+		event := game.Event{
+			Type:   game.EventJoined,
+			Player: player.ID,
+			Name:   player.Name,
+			Team:   player.Team,
+		}
+		if player.Handicap != (game.Handicap{}) {
+			event.Handicap = &player.Handicap
+		}
+		events = append(events, event)
+	}
+	events = append(events, game.Event{
+		Type:    game.EventStarted,
+		Score:   r.Target,
+		Opening: r.Opening,
+		NoUndo:  r.NoUndo,
+	})
+	return append(events, r.Actions...)
+}
+
+// Game replays the record. Rolls after the recorded ones use the seed,
+// when there is one, unless opts give other dice.
+func (r *Record) Game(opts ...game.GameOpt) (*game.Game, error) {
+	base := []game.GameOpt{game.WithGameID(r.ID)}
+	if r.Seed != nil {
+		random := game.NewSeededRandom(*r.Seed)
+		for _, action := range r.Actions {
+			for range action.Dice {
+				random()
+			}
+		}
+		base = append(base, game.WithRandom(random))
+	}
+	if r.NoUndo {
+		base = append(base, game.WithoutUndo())
+	}
+	return game.Replay(r.Events(), append(base, opts...)...)
+}
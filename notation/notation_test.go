@@ -0,0 +1,184 @@
+package notation_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/notation"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	seed := uint64(42)
+	var events []game.Event
+	g := game.NewGame(
+		game.WithGameID("g1"),
+		game.WithRandom(game.NewSeededRandom(seed)),
+		game.WithTarget(2_000),
+		game.WithoutUndo(),
+		game.WithListener(func(e game.Event) { events = append(events, e) }),
+	)
+	g.Join("Alice", game.WithID("a"))
+	g.Join("Bob", game.WithID("b"))
+	if err := strategy.Play(g, strategy.Threshold("a", 300, 3), strategy.Threshold("b", 500, 2)); err != nil {
+		t.Fatal(err)
+	}
+	record := notation.FromEvents(g.ID(), events, notation.WithSeed(seed))
+	var buf bytes.Buffer
+	if err := notation.Write(&buf, record); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	for _, tag := range []string{`[Seed "42"]`, `[Undo "off"]`} {
+		if !strings.Contains(text, tag) {
+			t.Errorf("missing %s:\n%s", tag, text)
+		}
+	}
+	parsed, err := notation.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(record, parsed); diff != "" {
+		t.Error("+want -got", diff)
+	}
+	replayed, err := parsed.Game()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replayed.Over() || replayed.Winner().ID() != g.Winner().ID() || replayed.Winner().Score() != g.Winner().Score() {
+		t.Errorf("replayed game differs:\n%s", text)
+	}
+}
+
+func TestWrite_Turns(t *testing.T) {
+	t.Parallel()
+	var events []game.Event
+	h := farkletest.New(t, game.WithGameID("g1"), game.WithListener(func(e game.Event) { events = append(events, e) }))
+	h.Start("Alice")
+	h.Play("roll 1 1 1 5 4 2; keep 0 1 2 3; bank; undo; bank; reject; roll 2 3 4 6 4 3")
+	var buf bytes.Buffer
+	if err := notation.Write(&buf, notation.FromEvents("g1", events)); err != nil {
+		t.Fatal(err)
+	}
+	want := `[Game "g1"]
+[Target "10000"]
+[Player "Alice" "Alice"]
+
+1. Alice R111542 K0123 B U B
+2. Alice X R234643 F
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("notation: +want -got\n%s", diff)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		text  string
+		score map[string]uint32
+		err   bool
+	}
+	for _, c := range []testCase{
+		{
+			name: "example",
+			text: `[Game "g1"]
+[Target "10000"]
+[Seed "42"]
+[Player "a" "Alice"]
+[Player "b" "Bob"]
+
+1. a R111542 K0123 B
+2. b A R15 K01 R236243 F
+3. a X R223344 K012345 R155236 K012 U K0 B ; thought better of it
+`,
+			score: map[string]uint32{"a": 1_950, "b": 0},
+		},
+		{
+			name: "teams",
+			text: `[Player "a" "Alice"]
+[Player "c" "Carol"]
+[Player "b" "Bob"]
+[Player "d" "Dan"]
+[Team "a" "red"]
+[Team "c" "red"]
+[Team "b" "blue"]
+[Team "d" "blue"]
+
+1. a R111542 K0123 B
+2. b A R15 K0 B
+3. c X R236243 F
+`,
+			score: map[string]uint32{"a": 350, "b": 450, "c": 0, "d": 0},
+		},
+		{
+			name: "handicap",
+			text: `[Target "1000"]
+[Opening "500"]
+[Player "a" "Alice"]
+[Player "b" "Bob"]
+[Handicap "a" "200" "200" "700"]
+
+1. a R111542 K0123 B
+2. b X R111542 K012 R555 K012 B
+`,
+			score: map[string]uint32{"a": 550, "b": 800},
+		},
+		{
+			name: "below opening",
+			text: "[Opening \"500\"]\n[Player \"a\" \"Alice\"]\n1. a R111542 K0123 B",
+			err:  true,
+		},
+		{
+			name: "team of unknown player",
+			text: "[Team \"a\" \"red\"]",
+			err:  true,
+		},
+		{
+			name: "unknown action",
+			text: "[Player \"a\" \"Alice\"]\n1. a R111542 Z",
+			err:  true,
+		},
+		{
+			name: "invalid die",
+			text: "[Player \"a\" \"Alice\"]\n1. a R711542",
+			err:  true,
+		},
+		{
+			name: "unknown tag",
+			text: "[Colour \"blue\"]",
+			err:  true,
+		},
+		{
+			name: "illegal keep",
+			text: "[Player \"a\" \"Alice\"]\n1. a R111542 K4",
+			err:  true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			record, err := notation.Parse(strings.NewReader(c.text))
+			var g *game.Game
+			if err == nil {
+				g, err = record.Game()
+			}
+			if !cmp.Equal(c.err, err != nil) {
+				t.Fatal("unexpected error", err)
+			}
+			if err != nil {
+				return
+			}
+			for _, player := range g.Players() {
+				if got := player.Score(); got != c.score[player.ID()] {
+					t.Errorf("score for %s: +want -got\n\t+%d\n\t-%d", player.ID(), c.score[player.ID()], got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package notation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Parse reads a record written in notation
+func Parse(r io.Reader) (*Record, error) {
+	record := &Record{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), ";")
+		text = strings.TrimSpace(text)
+		var err error
+		switch {
+		case text == "":
+		case strings.HasPrefix(text, "["):
+			err = record.parseTag(text)
+		default:
+			err = record.parseTurn(text)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return record, scanner.Err()
+}
+
+func (r *Record) parseTag(text string) error {
+	inner, ok := strings.CutSuffix(text[1:], "]")
+	if !ok {
+		return fmt.Errorf("unterminated tag %q", text)
+	}
+	key, rest, _ := strings.Cut(inner, " ")
+	var values []string
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", key, err)
+		}
+		value, _ := strconv.Unquote(quoted)
+		values = append(values, value)
+		rest = rest[len(quoted):]
+	}
+	want := map[string]int{"Game": 1, "Target": 1, "Seed": 1, "Undo": 1, "Opening": 1, "Player": 2, "Team": 2, "Handicap": 4, "Result": 2}
+	if n, ok := want[key]; !ok {
+		return fmt.Errorf("unknown tag %s", key)
+	} else if n != len(values) {
+		return fmt.Errorf("tag %s takes %d values", key, n)
+	}
+	switch key {
+	case "Game":
+		r.ID = values[0]
+	case "Target":
+		target, err := strconv.ParseUint(values[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", key, err)
+		}
+		r.Target = uint32(target)
+	case "Opening":
+		opening, err := strconv.ParseUint(values[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", key, err)
+		}
+		r.Opening = uint32(opening)
+	case "Seed":
+		seed, err := strconv.ParseUint(values[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", key, err)
+		}
+		r.Seed = &seed
+	case "Undo":
+		r.NoUndo = values[0] == "off"
+	case "Player":
+		r.Players = append(r.Players, Player{ID: values[0], Name: values[1]})
+	case "Team", "Handicap":
+		i := slices.IndexFunc(r.Players, func(p Player) bool { return p.ID == values[0] })
+		if i < 0 {
+			return fmt.Errorf("tag %s: unknown player %q", key, values[0])
+		}
+		if key == "Team" {
+			r.Players[i].Team = values[1]
+			return nil
+		}
+		var scores [3]uint32
+		for j, value := range values[1:] {
+			score, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("tag %s: %w", key, err)
+			}
+			scores[j] = uint32(score)
+		}
+		r.Players[i].Handicap = game.Handicap{Start: scores[0], Opening: scores[1], Target: scores[2]}
+	case "Result":
+		score, err := strconv.ParseUint(values[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", key, err)
+		}
+		r.Winner, r.Score = values[0], uint32(score)
+	}
+	return nil
+}
+
+func (r *Record) parseTurn(text string) error {
+	fields := strings.Fields(text)
+	if len(fields) < 2 || !strings.HasSuffix(fields[0], ".") {
+		return fmt.Errorf("turn should start with a number and a player: %q", text)
+	}
+	player := fields[1]
+	for _, field := range fields[2:] {
+		action := game.Event{Player: player}
+		switch field[0] {
+		case 'A':
+			action.Type = game.EventAccepted
+		case 'X':
+			action.Type = game.EventRejected
+		case 'B':
+			action.Type = game.EventBanked
+		case 'U':
+			action.Type = game.EventUndone
+		case 'S':
+			action.Type = game.EventSkipped
+		case 'F':
+			continue
+		case 'R':
+			action.Type = game.EventRolled
+			for _, c := range field[1:] {
+				if c < '1' || c > '6' {
+					return fmt.Errorf("invalid die %q in %q", c, field)
+				}
+				action.Dice = append(action.Dice, uint8(c-'0'))
+			}
+		case 'K':
+			action.Type = game.EventKept
+			for _, c := range field[1:] {
+				if c < '0' || c > '5' {
+					return fmt.Errorf("invalid index %q in %q", c, field)
+				}
+				action.Keep = append(action.Keep, int(c-'0'))
+			}
+		default:
+			return fmt.Errorf("unknown action %q", field)
+		}
+		if len(field) > 1 && action.Type != game.EventRolled && action.Type != game.EventKept {
+			return fmt.Errorf("unknown action %q", field)
+		}
+		r.Actions = append(r.Actions, action)
+	}
+	return nil
+}
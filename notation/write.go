@@ -0,0 +1,116 @@
+package notation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// Write writes the record in notation
+func Write(w io.Writer, r *Record) error {
+	bw := bufio.NewWriter(w)
+	tag := func(key string, values ...string) {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+		fmt.Fprintf(bw, "[%s %s]\n", key, strings.Join(quoted, " "))
+	}
+	if r.ID != "" {
+		tag("Game", r.ID)
+	}
+	tag("Target", strconv.FormatUint(uint64(r.Target), 10))
+	if r.Opening > 0 {
+		tag("Opening", strconv.FormatUint(uint64(r.Opening), 10))
+	}
+	if r.Seed != nil {
+		tag("Seed", strconv.FormatUint(*r.Seed, 10))
+	}
+	if r.NoUndo {
+		tag("Undo", "off")
+	}
+	for _, player := range r.Players {
+		tag("Player", player.ID, player.Name)
+	}
+	for _, player := range r.Players {
+		if player.Team != "" {
+			tag("Team", player.ID, player.Team)
+		}
+	}
+	for _, player := range r.Players {
+		if h := player.Handicap; h != (game.Handicap{}) {
+			tag("Handicap", player.ID, strconv.FormatUint(uint64(h.Start), 10), strconv.FormatUint(uint64(h.Opening), 10), strconv.FormatUint(uint64(h.Target), 10))
+		}
+	}
+	if r.Winner != "" {
+		tag("Result", r.Winner, strconv.FormatUint(uint64(r.Score), 10))
+	}
+	turn, player, ended := 0, "", false
+	for _, action := range r.Actions {
+		t, err := token(action)
+		if err != nil {
+			return err
+		}
+		// an undo stays on the line of the bank it takes back
+		if action.Player != player || ended && action.Type != game.EventUndone {
+			turn++
+			player = action.Player
+			fmt.Fprintf(bw, "\n%d. %s", turn, player)
+		}
+		bw.WriteString(" " + t)
+		ended = ends(action)
+	}
+	if turn > 0 {
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// ends reports whether the action ends its player's turn
+func ends(action game.Event) bool {
+	switch action.Type {
+	case game.EventBanked, game.EventSkipped:
+		return true
+	case game.EventRolled:
+		return action.Dice.Farkle()
+	}
+	return false
+}
+
+func token(action game.Event) (string, error) {
+	switch action.Type {
+	case game.EventAccepted:
+		return "A", nil
+	case game.EventRejected:
+		return "X", nil
+	case game.EventRolled:
+		var b strings.Builder
+		b.WriteString("R")
+		for _, d := range action.Dice {
+			b.WriteString(strconv.Itoa(int(d)))
+		}
+		if action.Dice.Farkle() {
+			b.WriteString(" F")
+		}
+		return b.String(), nil
+	case game.EventKept:
+		var b strings.Builder
+		b.WriteString("K")
+		for _, i := range action.Keep {
+			b.WriteString(strconv.Itoa(i))
+		}
+		return b.String(), nil
+	case game.EventBanked:
+		return "B", nil
+	case game.EventUndone:
+		return "U", nil
+	case game.EventSkipped:
+		return "S", nil
+	default:
+		return "", fmt.Errorf("no notation for %q", action.Type)
+	}
+}
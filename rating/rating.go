@@ -0,0 +1,179 @@
+// Package rating keeps multiplayer Elo ratings, updated from the final
+// placings of each finished game
+package rating
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	initialRating    = 1_500
+	provisionalGames = 10
+	k                = 32
+	provisionalK     = 64
+)
+
+var (
+	ErrTooFewPlayers = errors.New("at least two players are needed to rate a game")
+	ErrNotOver       = errors.New("game is not over")
+)
+
+// Pool separates ratings that should never be compared, such as bots and humans
+type Pool string
+
+const (
+	Humans Pool = "humans"
+	Bots   Pool = "bots"
+)
+
+type Opt func(*Ratings)
+
+// WithClassifier decides which pool each player is rated in. By default
+// every player is rated as a human.
+func WithClassifier(classify func(*game.Player) Pool) Opt {
+	return func(r *Ratings) {
+		r.classify = classify
+	}
+}
+
+// Entry is a single change in rating
+type Entry struct {
+	Rating float64
+	Delta  float64
+}
+
+type Rating struct {
+	Value   float64
+	Games   int
+	History []Entry
+}
+
+// Provisional reports whether too few games have been played for the
+// rating to be trusted. Provisional ratings move faster.
+func (r Rating) Provisional() bool {
+	return r.Games < provisionalGames
+}
+
+type Ratings struct {
+	mu       sync.Mutex
+	pools    map[Pool]map[string]*Rating
+	classify func(*game.Player) Pool
+}
+
+// Update rates every player in the game against every other, scoring a
+// win, loss or draw for each pair according to final placement. In a team
+// game players are placed by their team's total and not rated against
+// their teammates.
+func (r *Ratings) Update(g *game.Game) error {
+	players := g.Players()
+	if len(players) < 2 {
+		return ErrTooFewPlayers
+	}
+	if !g.Over() {
+		return ErrNotOver
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ratings := make([]*Rating, len(players))
+	for i, player := range players {
+		ratings[i] = r.get(r.classify(player), player.ID())
+	}
+	deltas := make([]float64, len(players))
+	for i, player := range players {
+		var sum float64
+		opponents := 0
+		for j, opponent := range players {
+			if i == j || (player.Team() != "" && player.Team() == opponent.Team()) {
+				continue
+			}
+			sum += actual(g.Compare(player, opponent)) - expected(ratings[i].Value, ratings[j].Value)
+			opponents++
+		}
+		if opponents == 0 {
+			continue
+		}
+		factor := float64(k)
+		if ratings[i].Provisional() {
+			factor = provisionalK
+		}
+		deltas[i] = factor * sum / float64(opponents)
+	}
+	for i, rating := range ratings {
+		rating.Value += deltas[i]
+		rating.Games++
+		rating.History = append(rating.History, Entry{Rating: rating.Value, Delta: deltas[i]})
+	}
+	return nil
+}
+
+// Rating returns a copy of the player's rating in the given pool, the
+// initial rating for players who have not been rated in it
+func (r *Ratings) Rating(pool Pool, id string) Rating {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rating, ok := r.pools[pool][id]
+	if !ok {
+		return Rating{Value: initialRating}
+	}
+	ret := *rating
+	ret.History = append([]Entry(nil), rating.History...)
+	return ret
+}
+
+// Leaderboard returns the identities in a pool, highest rated first and
+// by identity among equals
+func (r *Ratings) Leaderboard(pool Pool) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := slices.Sorted(maps.Keys(r.pools[pool]))
+	slices.SortStableFunc(ret, func(a, b string) int {
+		return cmp.Compare(r.pools[pool][b].Value, r.pools[pool][a].Value)
+	})
+	return ret
+}
+
+func (r *Ratings) get(pool Pool, id string) *Rating {
+	if r.pools[pool] == nil {
+		r.pools[pool] = make(map[string]*Rating)
+	}
+	rating, ok := r.pools[pool][id]
+	if !ok {
+		rating = &Rating{Value: initialRating}
+		r.pools[pool][id] = rating
+	}
+	return rating
+}
+
+// actual scores a game won, lost or drawn against an opponent
+func actual(compared int) float64 {
+	switch {
+	case compared > 0:
+		return 1
+	case compared < 0:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+func expected(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
+
+func New(opts ...Opt) *Ratings {
+	ratings := &Ratings{
+		pools:    make(map[Pool]map[string]*Rating),
+		classify: func(*game.Player) Pool { return Humans },
+	}
+	for _, opt := range opts {
+		opt(ratings)
+	}
+	return ratings
+}
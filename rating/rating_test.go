@@ -0,0 +1,144 @@
+package rating_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rating"
+)
+
+func TestRatings_Update(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		players []string
+		teams   []string
+		opts    []rating.Opt
+		want    map[string]float64
+		board   []string
+		pool    rating.Pool
+		err     bool
+	}
+	for _, c := range []testCase{
+		{
+			name:    "winner takes from loser",
+			players: []string{"alice", "bob"},
+			want:    map[string]float64{"alice": 1532, "bob": 1468},
+			pool:    rating.Humans,
+		},
+		{
+			name:    "tied losers share the loss",
+			players: []string{"alice", "bob", "carol"},
+			want:    map[string]float64{"alice": 1532, "bob": 1484, "carol": 1484},
+			board:   []string{"alice", "bob", "carol"},
+			pool:    rating.Humans,
+		},
+		{
+			name:    "bots rated separately",
+			players: []string{"bot-alice", "bot-bob"},
+			opts: []rating.Opt{rating.WithClassifier(func(p *game.Player) rating.Pool {
+				if strings.HasPrefix(p.Name(), "bot-") {
+					return rating.Bots
+				}
+				return rating.Humans
+			})},
+			want: map[string]float64{"bot-alice": 1532, "bot-bob": 1468},
+			pool: rating.Bots,
+		},
+		{
+			name:    "teammates share the win",
+			players: []string{"alice", "bob", "carol", "dave"},
+			teams:   []string{"red", "blue", "red", "blue"},
+			want:    map[string]float64{"alice": 1532, "bob": 1468, "carol": 1532, "dave": 1468},
+			pool:    rating.Humans,
+		},
+		{
+			name:    "too few players",
+			players: []string{"alice"},
+			err:     true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 2, 3, 4, 6, 4, 3, 2, 3, 4, 6, 4, 3, 2, 3, 4, 6, 4, 3).Roll), game.WithTarget(300))
+			for i, name := range c.players {
+				opts := []game.PlayerOpt{game.WithID(name)}
+				if c.teams != nil {
+					opts = append(opts, game.WithTeam(c.teams[i]))
+				}
+				g.Join(name, opts...)
+			}
+			if err := g.Start(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Roll(); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Keep(0, 1, 2, 3); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Bank(); err != nil {
+				t.Fatal(err)
+			}
+			for range len(c.players) - 1 {
+				if err := g.Reject(); err != nil {
+					t.Fatal(err)
+				}
+				if err := g.Roll(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ratings := rating.New(c.opts...)
+			err := ratings.Update(g)
+			if !cmp.Equal(c.err, err != nil) {
+				t.Fatal("unexpected error", err)
+			}
+			for id, want := range c.want {
+				got := ratings.Rating(c.pool, id)
+				if got.Value != want {
+					t.Errorf("rating for %s: +want -got\n\t+%v\n\t-%v", id, want, got.Value)
+				}
+				if !got.Provisional() || len(got.History) != 1 {
+					t.Errorf("unexpected rating for %s: %+v", id, got)
+				}
+			}
+			if c.board != nil {
+				if diff := cmp.Diff(c.board, ratings.Leaderboard(c.pool)); diff != "" {
+					t.Errorf("leaderboard: +want -got\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRatings_UpdateUnfinished(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll))
+	g.Join("alice")
+	g.Join("bob")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	ratings := rating.New()
+	if err := ratings.Update(g); !errors.Is(err, rating.ErrNotOver) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", rating.ErrNotOver, err)
+	}
+	if got := ratings.Leaderboard(rating.Humans); len(got) != 0 {
+		t.Errorf("rated an unfinished game: %v", got)
+	}
+}
+
+func TestRatings_Rating(t *testing.T) {
+	t.Parallel()
+	ratings := rating.New()
+	if got := ratings.Rating(rating.Humans, "alice"); got.Value != 1500 || got.Games != 0 {
+		t.Errorf("unrated player: %+v", got)
+	}
+	if got := ratings.Leaderboard(rating.Humans); len(got) != 0 {
+		t.Errorf("looking up a rating added to the leaderboard: %v", got)
+	}
+}
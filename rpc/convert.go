@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rpc/farklepb"
+)
+
+// state converts a snapshot of the game to its message
+func state(g *game.Game) *farklepb.GameState {
+	s := g.Snapshot()
+	ret := &farklepb.GameState{
+		Id:         s.ID,
+		State:      stage(s.State),
+		Target:     s.Target,
+		Current:    int32(s.Current),
+		FinalRound: s.FinalRound,
+		OfferDice:  int32(s.OfferDice),
+		OfferScore: s.OfferScore,
+		Dice:       dice(s.Dice),
+		Held:       ints(s.Held),
+		Turn:       s.Turn,
+		Available:  int32(s.Available),
+		Winner:     int32(s.Winner),
+	}
+	for _, player := range s.Players {
+		ret.Players = append(ret.Players, &farklepb.Player{
+			Id:     player.ID,
+			Name:   player.Name,
+			Team:   player.Team,
+			Score:  player.Score,
+			Turns:  int32(player.Turns),
+			Target: player.Target,
+		})
+	}
+	return ret
+}
+
+// stage converts the state of a game to its enum, which is unspecified
+// for states the protocol doesn't have
+func stage(s game.State) farklepb.State {
+	switch s {
+	case game.AwaitingDecision:
+		return farklepb.State_STATE_AWAITING_DECISION
+	case game.AwaitingRoll:
+		return farklepb.State_STATE_AWAITING_ROLL
+	case game.AwaitingKeep:
+		return farklepb.State_STATE_AWAITING_KEEP
+	case game.Banked:
+		return farklepb.State_STATE_BANKED
+	case game.Farkled:
+		return farklepb.State_STATE_FARKLED
+	case game.GameOver:
+		return farklepb.State_STATE_GAME_OVER
+	default:
+		return farklepb.State_STATE_UNSPECIFIED
+	}
+}
+
+func message(event game.Event) *farklepb.Event {
+	return &farklepb.Event{
+		Type:      string(event.Type),
+		Player:    event.Player,
+		Name:      event.Name,
+		Dice:      dice(event.Dice),
+		Keep:      ints(event.Keep),
+		Score:     event.Score,
+		Available: int32(event.Available),
+		Team:      event.Team,
+	}
+}
+
+func dice(roll game.Roll) []uint32 {
+	if roll == nil {
+		return nil
+	}
+	ret := make([]uint32, len(roll))
+	for i, die := range roll {
+		ret[i] = uint32(die)
+	}
+	return ret
+}
+
+func ints(values []int) []int32 {
+	if values == nil {
+		return nil
+	}
+	ret := make([]int32, len(values))
+	for i, v := range values {
+		ret[i] = int32(v)
+	}
+	return ret
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rpc/farklepb"
+)
+
+func TestStage(t *testing.T) {
+	t.Parallel()
+	want := map[game.State]farklepb.State{
+		game.AwaitingDecision: farklepb.State_STATE_AWAITING_DECISION,
+		game.AwaitingRoll:     farklepb.State_STATE_AWAITING_ROLL,
+		game.AwaitingKeep:     farklepb.State_STATE_AWAITING_KEEP,
+		game.Banked:           farklepb.State_STATE_BANKED,
+		game.Farkled:          farklepb.State_STATE_FARKLED,
+		game.GameOver:         farklepb.State_STATE_GAME_OVER,
+	}
+	// every state with a name needs a message
+	for s := game.State(0); s.String() != "unknown"; s++ {
+		if _, ok := want[s]; !ok {
+			t.Errorf("state %q has no expected message", s)
+		}
+	}
+	for s, want := range want {
+		if got := stage(s); got != want {
+			t.Errorf("%s: +want -got\n\t+%v\n\t-%v", s, want, got)
+		}
+	}
+	if got := stage(game.State(-1)); got != farklepb.State_STATE_UNSPECIFIED {
+		t.Errorf("unknown state: +want -got\n\t+%v\n\t-%v", farklepb.State_STATE_UNSPECIFIED, got)
+	}
+}
@@ -0,0 +1,915 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: rpc/farklepb/farkle.proto
+
+package farklepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED       State = 0
+	State_STATE_AWAITING_DECISION State = 1
+	State_STATE_AWAITING_ROLL     State = 2
+	State_STATE_AWAITING_KEEP     State = 3
+	State_STATE_BANKED            State = 4
+	State_STATE_FARKLED           State = 5
+	State_STATE_GAME_OVER         State = 6
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_AWAITING_DECISION",
+		2: "STATE_AWAITING_ROLL",
+		3: "STATE_AWAITING_KEEP",
+		4: "STATE_BANKED",
+		5: "STATE_FARKLED",
+		6: "STATE_GAME_OVER",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED":       0,
+		"STATE_AWAITING_DECISION": 1,
+		"STATE_AWAITING_ROLL":     2,
+		"STATE_AWAITING_KEEP":     3,
+		"STATE_BANKED":            4,
+		"STATE_FARKLED":           5,
+		"STATE_GAME_OVER":         6,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_farklepb_farkle_proto_enumTypes[0].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_rpc_farklepb_farkle_proto_enumTypes[0]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateGameRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// target is the score that triggers the final round, 10000 when unset
+	Target uint32 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	NoUndo bool   `protobuf:"varint,2,opt,name=no_undo,json=noUndo,proto3" json:"no_undo,omitempty"`
+	// opening is the score a player must bank in one turn to get on the board
+	Opening       uint32 `protobuf:"varint,3,opt,name=opening,proto3" json:"opening,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateGameRequest) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *CreateGameRequest) GetNoUndo() bool {
+	if x != nil {
+		return x.NoUndo
+	}
+	return false
+}
+
+func (x *CreateGameRequest) GetOpening() uint32 {
+	if x != nil {
+		return x.Opening
+	}
+	return 0
+}
+
+type JoinGameRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	GameId string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// player_id is a stable identity for the player, generated when unset
+	PlayerId      string `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Team          string `protobuf:"bytes,4,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameRequest) Reset() {
+	*x = JoinGameRequest{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameRequest) ProtoMessage() {}
+
+func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
+func (*JoinGameRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JoinGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+type JoinGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	State         *GameState             `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameResponse) Reset() {
+	*x = JoinGameResponse{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameResponse) ProtoMessage() {}
+
+func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
+func (*JoinGameResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JoinGameResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *JoinGameResponse) GetState() *GameState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type GameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameRequest) Reset() {
+	*x = GameRequest{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameRequest) ProtoMessage() {}
+
+func (x *GameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameRequest.ProtoReflect.Descriptor instead.
+func (*GameRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type ActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionRequest) Reset() {
+	*x = ActionRequest{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionRequest) ProtoMessage() {}
+
+func (x *ActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionRequest.ProtoReflect.Descriptor instead.
+func (*ActionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActionRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ActionRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type KeepRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GameId   string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// dice are the indexes of the dice to keep from the most recent roll
+	Dice          []int32 `protobuf:"varint,3,rep,packed,name=dice,proto3" json:"dice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeepRequest) Reset() {
+	*x = KeepRequest{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepRequest) ProtoMessage() {}
+
+func (x *KeepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepRequest.ProtoReflect.Descriptor instead.
+func (*KeepRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *KeepRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *KeepRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *KeepRequest) GetDice() []int32 {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+type Player struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Team  string                 `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	Score uint32                 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Turns int32                  `protobuf:"varint,5,opt,name=turns,proto3" json:"turns,omitempty"`
+	// target is the score the player must reach, after any handicap
+	Target        uint32 `protobuf:"varint,6,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Player) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Player) GetTurns() int32 {
+	if x != nil {
+		return x.Turns
+	}
+	return 0
+}
+
+func (x *Player) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+type GameState struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State   State                  `protobuf:"varint,2,opt,name=state,proto3,enum=farkle.v1.State" json:"state,omitempty"`
+	Target  uint32                 `protobuf:"varint,3,opt,name=target,proto3" json:"target,omitempty"`
+	Players []*Player              `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	// current is the seat of the player whose turn it is
+	Current    int32 `protobuf:"varint,5,opt,name=current,proto3" json:"current,omitempty"`
+	FinalRound bool  `protobuf:"varint,6,opt,name=final_round,json=finalRound,proto3" json:"final_round,omitempty"`
+	// offer_dice and offer_score are what the previous player left
+	OfferDice  int32  `protobuf:"varint,7,opt,name=offer_dice,json=offerDice,proto3" json:"offer_dice,omitempty"`
+	OfferScore uint32 `protobuf:"varint,8,opt,name=offer_score,json=offerScore,proto3" json:"offer_score,omitempty"`
+	// dice is the current turn's most recent roll, held the indexes kept
+	Dice      []uint32 `protobuf:"varint,9,rep,packed,name=dice,proto3" json:"dice,omitempty"`
+	Held      []int32  `protobuf:"varint,10,rep,packed,name=held,proto3" json:"held,omitempty"`
+	Turn      uint32   `protobuf:"varint,11,opt,name=turn,proto3" json:"turn,omitempty"`
+	Available int32    `protobuf:"varint,12,opt,name=available,proto3" json:"available,omitempty"`
+	// winner is the seat of the winner once the game is over, or -1
+	Winner        int32 `protobuf:"varint,13,opt,name=winner,proto3" json:"winner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameState) Reset() {
+	*x = GameState{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameState) ProtoMessage() {}
+
+func (x *GameState) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
+func (*GameState) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameState) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GameState) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *GameState) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *GameState) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GameState) GetCurrent() int32 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *GameState) GetFinalRound() bool {
+	if x != nil {
+		return x.FinalRound
+	}
+	return false
+}
+
+func (x *GameState) GetOfferDice() int32 {
+	if x != nil {
+		return x.OfferDice
+	}
+	return 0
+}
+
+func (x *GameState) GetOfferScore() uint32 {
+	if x != nil {
+		return x.OfferScore
+	}
+	return 0
+}
+
+func (x *GameState) GetDice() []uint32 {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *GameState) GetHeld() []int32 {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+func (x *GameState) GetTurn() uint32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *GameState) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *GameState) GetWinner() int32 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Player        string                 `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Dice          []uint32               `protobuf:"varint,4,rep,packed,name=dice,proto3" json:"dice,omitempty"`
+	Keep          []int32                `protobuf:"varint,5,rep,packed,name=keep,proto3" json:"keep,omitempty"`
+	Score         uint32                 `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	Available     int32                  `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	Team          string                 `protobuf:"bytes,8,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_farklepb_farkle_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_rpc_farklepb_farkle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Event) GetDice() []uint32 {
+	if x != nil {
+		return x.Dice
+	}
+	return nil
+}
+
+func (x *Event) GetKeep() []int32 {
+	if x != nil {
+		return x.Keep
+	}
+	return nil
+}
+
+func (x *Event) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Event) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Event) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+var File_rpc_farklepb_farkle_proto protoreflect.FileDescriptor
+
+const file_rpc_farklepb_farkle_proto_rawDesc = "" +
+	"\n" +
+	"\x19rpc/farklepb/farkle.proto\x12\tfarkle.v1\"^\n" +
+	"\x11CreateGameRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\rR\x06target\x12\x17\n" +
+	"\ano_undo\x18\x02 \x01(\bR\x06noUndo\x12\x18\n" +
+	"\aopening\x18\x03 \x01(\rR\aopening\"o\n" +
+	"\x0fJoinGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12\x12\n" +
+	"\x04team\x18\x04 \x01(\tR\x04team\"[\n" +
+	"\x10JoinGameResponse\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12*\n" +
+	"\x05state\x18\x02 \x01(\v2\x14.farkle.v1.GameStateR\x05state\"&\n" +
+	"\vGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"E\n" +
+	"\rActionRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"W\n" +
+	"\vKeepRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x12\n" +
+	"\x04dice\x18\x03 \x03(\x05R\x04dice\"\x84\x01\n" +
+	"\x06Player\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\tR\x04team\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\rR\x05score\x12\x14\n" +
+	"\x05turns\x18\x05 \x01(\x05R\x05turns\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\rR\x06target\"\xf5\x02\n" +
+	"\tGameState\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x10.farkle.v1.StateR\x05state\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\rR\x06target\x12+\n" +
+	"\aplayers\x18\x04 \x03(\v2\x11.farkle.v1.PlayerR\aplayers\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\x05R\acurrent\x12\x1f\n" +
+	"\vfinal_round\x18\x06 \x01(\bR\n" +
+	"finalRound\x12\x1d\n" +
+	"\n" +
+	"offer_dice\x18\a \x01(\x05R\tofferDice\x12\x1f\n" +
+	"\voffer_score\x18\b \x01(\rR\n" +
+	"offerScore\x12\x12\n" +
+	"\x04dice\x18\t \x03(\rR\x04dice\x12\x12\n" +
+	"\x04held\x18\n" +
+	" \x03(\x05R\x04held\x12\x12\n" +
+	"\x04turn\x18\v \x01(\rR\x04turn\x12\x1c\n" +
+	"\tavailable\x18\f \x01(\x05R\tavailable\x12\x16\n" +
+	"\x06winner\x18\r \x01(\x05R\x06winner\"\xb7\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06player\x18\x02 \x01(\tR\x06player\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04dice\x18\x04 \x03(\rR\x04dice\x12\x12\n" +
+	"\x04keep\x18\x05 \x03(\x05R\x04keep\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\rR\x05score\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailable\x12\x12\n" +
+	"\x04team\x18\b \x01(\tR\x04team*\xa7\x01\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17STATE_AWAITING_DECISION\x10\x01\x12\x17\n" +
+	"\x13STATE_AWAITING_ROLL\x10\x02\x12\x17\n" +
+	"\x13STATE_AWAITING_KEEP\x10\x03\x12\x10\n" +
+	"\fSTATE_BANKED\x10\x04\x12\x11\n" +
+	"\rSTATE_FARKLED\x10\x05\x12\x13\n" +
+	"\x0fSTATE_GAME_OVER\x10\x062\xd6\x04\n" +
+	"\x06Farkle\x12@\n" +
+	"\n" +
+	"CreateGame\x12\x1c.farkle.v1.CreateGameRequest\x1a\x14.farkle.v1.GameState\x12C\n" +
+	"\bJoinGame\x12\x1a.farkle.v1.JoinGameRequest\x1a\x1b.farkle.v1.JoinGameResponse\x129\n" +
+	"\tStartGame\x12\x16.farkle.v1.GameRequest\x1a\x14.farkle.v1.GameState\x127\n" +
+	"\aGetGame\x12\x16.farkle.v1.GameRequest\x1a\x14.farkle.v1.GameState\x128\n" +
+	"\x06Accept\x12\x18.farkle.v1.ActionRequest\x1a\x14.farkle.v1.GameState\x128\n" +
+	"\x06Reject\x12\x18.farkle.v1.ActionRequest\x1a\x14.farkle.v1.GameState\x126\n" +
+	"\x04Roll\x12\x18.farkle.v1.ActionRequest\x1a\x14.farkle.v1.GameState\x124\n" +
+	"\x04Keep\x12\x16.farkle.v1.KeepRequest\x1a\x14.farkle.v1.GameState\x126\n" +
+	"\x04Bank\x12\x18.farkle.v1.ActionRequest\x1a\x14.farkle.v1.GameState\x127\n" +
+	"\tSubscribe\x12\x16.farkle.v1.GameRequest\x1a\x10.farkle.v1.Event0\x01B.Z,github.com/ryannatesmith/farkle/rpc/farklepbb\x06proto3"
+
+var (
+	file_rpc_farklepb_farkle_proto_rawDescOnce sync.Once
+	file_rpc_farklepb_farkle_proto_rawDescData []byte
+)
+
+func file_rpc_farklepb_farkle_proto_rawDescGZIP() []byte {
+	file_rpc_farklepb_farkle_proto_rawDescOnce.Do(func() {
+		file_rpc_farklepb_farkle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_farklepb_farkle_proto_rawDesc), len(file_rpc_farklepb_farkle_proto_rawDesc)))
+	})
+	return file_rpc_farklepb_farkle_proto_rawDescData
+}
+
+var file_rpc_farklepb_farkle_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rpc_farklepb_farkle_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_rpc_farklepb_farkle_proto_goTypes = []any{
+	(State)(0),                // 0: farkle.v1.State
+	(*CreateGameRequest)(nil), // 1: farkle.v1.CreateGameRequest
+	(*JoinGameRequest)(nil),   // 2: farkle.v1.JoinGameRequest
+	(*JoinGameResponse)(nil),  // 3: farkle.v1.JoinGameResponse
+	(*GameRequest)(nil),       // 4: farkle.v1.GameRequest
+	(*ActionRequest)(nil),     // 5: farkle.v1.ActionRequest
+	(*KeepRequest)(nil),       // 6: farkle.v1.KeepRequest
+	(*Player)(nil),            // 7: farkle.v1.Player
+	(*GameState)(nil),         // 8: farkle.v1.GameState
+	(*Event)(nil),             // 9: farkle.v1.Event
+}
+var file_rpc_farklepb_farkle_proto_depIdxs = []int32{
+	8,  // 0: farkle.v1.JoinGameResponse.state:type_name -> farkle.v1.GameState
+	0,  // 1: farkle.v1.GameState.state:type_name -> farkle.v1.State
+	7,  // 2: farkle.v1.GameState.players:type_name -> farkle.v1.Player
+	1,  // 3: farkle.v1.Farkle.CreateGame:input_type -> farkle.v1.CreateGameRequest
+	2,  // 4: farkle.v1.Farkle.JoinGame:input_type -> farkle.v1.JoinGameRequest
+	4,  // 5: farkle.v1.Farkle.StartGame:input_type -> farkle.v1.GameRequest
+	4,  // 6: farkle.v1.Farkle.GetGame:input_type -> farkle.v1.GameRequest
+	5,  // 7: farkle.v1.Farkle.Accept:input_type -> farkle.v1.ActionRequest
+	5,  // 8: farkle.v1.Farkle.Reject:input_type -> farkle.v1.ActionRequest
+	5,  // 9: farkle.v1.Farkle.Roll:input_type -> farkle.v1.ActionRequest
+	6,  // 10: farkle.v1.Farkle.Keep:input_type -> farkle.v1.KeepRequest
+	5,  // 11: farkle.v1.Farkle.Bank:input_type -> farkle.v1.ActionRequest
+	4,  // 12: farkle.v1.Farkle.Subscribe:input_type -> farkle.v1.GameRequest
+	8,  // 13: farkle.v1.Farkle.CreateGame:output_type -> farkle.v1.GameState
+	3,  // 14: farkle.v1.Farkle.JoinGame:output_type -> farkle.v1.JoinGameResponse
+	8,  // 15: farkle.v1.Farkle.StartGame:output_type -> farkle.v1.GameState
+	8,  // 16: farkle.v1.Farkle.GetGame:output_type -> farkle.v1.GameState
+	8,  // 17: farkle.v1.Farkle.Accept:output_type -> farkle.v1.GameState
+	8,  // 18: farkle.v1.Farkle.Reject:output_type -> farkle.v1.GameState
+	8,  // 19: farkle.v1.Farkle.Roll:output_type -> farkle.v1.GameState
+	8,  // 20: farkle.v1.Farkle.Keep:output_type -> farkle.v1.GameState
+	8,  // 21: farkle.v1.Farkle.Bank:output_type -> farkle.v1.GameState
+	9,  // 22: farkle.v1.Farkle.Subscribe:output_type -> farkle.v1.Event
+	13, // [13:23] is the sub-list for method output_type
+	3,  // [3:13] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_farklepb_farkle_proto_init() }
+func file_rpc_farklepb_farkle_proto_init() {
+	if File_rpc_farklepb_farkle_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_farklepb_farkle_proto_rawDesc), len(file_rpc_farklepb_farkle_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_farklepb_farkle_proto_goTypes,
+		DependencyIndexes: file_rpc_farklepb_farkle_proto_depIdxs,
+		EnumInfos:         file_rpc_farklepb_farkle_proto_enumTypes,
+		MessageInfos:      file_rpc_farklepb_farkle_proto_msgTypes,
+	}.Build()
+	File_rpc_farklepb_farkle_proto = out.File
+	file_rpc_farklepb_farkle_proto_goTypes = nil
+	file_rpc_farklepb_farkle_proto_depIdxs = nil
+}
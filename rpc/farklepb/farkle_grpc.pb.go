@@ -0,0 +1,477 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rpc/farklepb/farkle.proto
+
+package farklepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Farkle_CreateGame_FullMethodName = "/farkle.v1.Farkle/CreateGame"
+	Farkle_JoinGame_FullMethodName   = "/farkle.v1.Farkle/JoinGame"
+	Farkle_StartGame_FullMethodName  = "/farkle.v1.Farkle/StartGame"
+	Farkle_GetGame_FullMethodName    = "/farkle.v1.Farkle/GetGame"
+	Farkle_Accept_FullMethodName     = "/farkle.v1.Farkle/Accept"
+	Farkle_Reject_FullMethodName     = "/farkle.v1.Farkle/Reject"
+	Farkle_Roll_FullMethodName       = "/farkle.v1.Farkle/Roll"
+	Farkle_Keep_FullMethodName       = "/farkle.v1.Farkle/Keep"
+	Farkle_Bank_FullMethodName       = "/farkle.v1.Farkle/Bank"
+	Farkle_Subscribe_FullMethodName  = "/farkle.v1.Farkle/Subscribe"
+)
+
+// FarkleClient is the client API for Farkle service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Farkle plays games held by the server. Actions name the player taking
+// them, who must be the player whose turn it is.
+type FarkleClient interface {
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*GameState, error)
+	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
+	StartGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*GameState, error)
+	GetGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*GameState, error)
+	Accept(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error)
+	Reject(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error)
+	Roll(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error)
+	Keep(ctx context.Context, in *KeepRequest, opts ...grpc.CallOption) (*GameState, error)
+	Bank(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error)
+	// Subscribe streams every event of the game so far and then each one as
+	// it happens, ending once the game is over.
+	Subscribe(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type farkleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFarkleClient(cc grpc.ClientConnInterface) FarkleClient {
+	return &farkleClient{cc}
+}
+
+func (c *farkleClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_CreateGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinGameResponse)
+	err := c.cc.Invoke(ctx, Farkle_JoinGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) StartGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_StartGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) GetGame(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_GetGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Accept(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_Accept_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Reject(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_Reject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Roll(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_Roll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Keep(ctx context.Context, in *KeepRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_Keep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Bank(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (*GameState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Farkle_Bank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *farkleClient) Subscribe(ctx context.Context, in *GameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Farkle_ServiceDesc.Streams[0], Farkle_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GameRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Farkle_SubscribeClient = grpc.ServerStreamingClient[Event]
+
+// FarkleServer is the server API for Farkle service.
+// All implementations must embed UnimplementedFarkleServer
+// for forward compatibility.
+//
+// Farkle plays games held by the server. Actions name the player taking
+// them, who must be the player whose turn it is.
+type FarkleServer interface {
+	CreateGame(context.Context, *CreateGameRequest) (*GameState, error)
+	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
+	StartGame(context.Context, *GameRequest) (*GameState, error)
+	GetGame(context.Context, *GameRequest) (*GameState, error)
+	Accept(context.Context, *ActionRequest) (*GameState, error)
+	Reject(context.Context, *ActionRequest) (*GameState, error)
+	Roll(context.Context, *ActionRequest) (*GameState, error)
+	Keep(context.Context, *KeepRequest) (*GameState, error)
+	Bank(context.Context, *ActionRequest) (*GameState, error)
+	// Subscribe streams every event of the game so far and then each one as
+	// it happens, ending once the game is over.
+	Subscribe(*GameRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedFarkleServer()
+}
+
+// UnimplementedFarkleServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFarkleServer struct{}
+
+func (UnimplementedFarkleServer) CreateGame(context.Context, *CreateGameRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGame not implemented")
+}
+func (UnimplementedFarkleServer) JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinGame not implemented")
+}
+func (UnimplementedFarkleServer) StartGame(context.Context, *GameRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedFarkleServer) GetGame(context.Context, *GameRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGame not implemented")
+}
+func (UnimplementedFarkleServer) Accept(context.Context, *ActionRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Accept not implemented")
+}
+func (UnimplementedFarkleServer) Reject(context.Context, *ActionRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (UnimplementedFarkleServer) Roll(context.Context, *ActionRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Roll not implemented")
+}
+func (UnimplementedFarkleServer) Keep(context.Context, *KeepRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keep not implemented")
+}
+func (UnimplementedFarkleServer) Bank(context.Context, *ActionRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bank not implemented")
+}
+func (UnimplementedFarkleServer) Subscribe(*GameRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedFarkleServer) mustEmbedUnimplementedFarkleServer() {}
+func (UnimplementedFarkleServer) testEmbeddedByValue()                {}
+
+// UnsafeFarkleServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FarkleServer will
+// result in compilation errors.
+type UnsafeFarkleServer interface {
+	mustEmbedUnimplementedFarkleServer()
+}
+
+func RegisterFarkleServer(s grpc.ServiceRegistrar, srv FarkleServer) {
+	// If the following call pancis, it indicates UnimplementedFarkleServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Farkle_ServiceDesc, srv)
+}
+
+func _Farkle_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_CreateGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).JoinGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_JoinGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).JoinGame(ctx, req.(*JoinGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_StartGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).StartGame(ctx, req.(*GameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_GetGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).GetGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_GetGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).GetGame(ctx, req.(*GameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Accept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).Accept(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_Accept_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).Accept(ctx, req.(*ActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_Reject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).Reject(ctx, req.(*ActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Roll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).Roll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_Roll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).Roll(ctx, req.(*ActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Keep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).Keep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_Keep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).Keep(ctx, req.(*KeepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Bank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FarkleServer).Bank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Farkle_Bank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FarkleServer).Bank(ctx, req.(*ActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Farkle_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FarkleServer).Subscribe(m, &grpc.GenericServerStream[GameRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Farkle_SubscribeServer = grpc.ServerStreamingServer[Event]
+
+// Farkle_ServiceDesc is the grpc.ServiceDesc for Farkle service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Farkle_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "farkle.v1.Farkle",
+	HandlerType: (*FarkleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGame",
+			Handler:    _Farkle_CreateGame_Handler,
+		},
+		{
+			MethodName: "JoinGame",
+			Handler:    _Farkle_JoinGame_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _Farkle_StartGame_Handler,
+		},
+		{
+			MethodName: "GetGame",
+			Handler:    _Farkle_GetGame_Handler,
+		},
+		{
+			MethodName: "Accept",
+			Handler:    _Farkle_Accept_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _Farkle_Reject_Handler,
+		},
+		{
+			MethodName: "Roll",
+			Handler:    _Farkle_Roll_Handler,
+		},
+		{
+			MethodName: "Keep",
+			Handler:    _Farkle_Keep_Handler,
+		},
+		{
+			MethodName: "Bank",
+			Handler:    _Farkle_Bank_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Farkle_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/farklepb/farkle.proto",
+}
@@ -0,0 +1,5 @@
+// Package farklepb holds the protobuf messages and gRPC service of
+// farkle.proto, generated with protoc-gen-go and protoc-gen-go-grpc.
+package farklepb
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative rpc/farklepb/farkle.proto
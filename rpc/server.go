@@ -0,0 +1,261 @@
+// Package rpc serves games over gRPC, using the Farkle service defined in
+// farklepb. Clients are generated alongside it with farklepb.NewFarkleClient.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rpc/farklepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// backlog is the number of events a subscriber can fall behind by before
+// their stream is ended
+const backlog = 256
+
+type Opt func(*Server)
+
+// WithGameOpts sets options every game is created with, after those of
+// the request
+func WithGameOpts(opts ...game.GameOpt) Opt {
+	return func(s *Server) {
+		s.gameOpts = opts
+	}
+}
+
+// entry is a game along with its events so far and those watching it. Its
+// lock is held for each action, so games are played independently.
+type entry struct {
+	mu          sync.Mutex
+	game        *game.Game
+	started     bool
+	events      []game.Event
+	subscribers map[chan game.Event]bool
+}
+
+func (e *entry) listen(event game.Event) {
+	e.events = append(e.events, event)
+	for subscriber := range e.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			// too far behind to keep up, so end their stream
+			delete(e.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+	if event.Type == game.EventEnded {
+		for subscriber := range e.subscribers {
+			delete(e.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+type Server struct {
+	farklepb.UnimplementedFarkleServer
+	mu       sync.Mutex
+	gameOpts []game.GameOpt
+	games    map[string]*entry
+}
+
+func (s *Server) CreateGame(ctx context.Context, req *farklepb.CreateGameRequest) (*farklepb.GameState, error) {
+	e := &entry{subscribers: make(map[chan game.Event]bool)}
+	opts := []game.GameOpt{game.WithListener(e.listen), game.WithOpening(req.GetOpening())}
+	if req.GetTarget() > 0 {
+		opts = append(opts, game.WithTarget(req.GetTarget()))
+	}
+	if req.GetNoUndo() {
+		opts = append(opts, game.WithoutUndo())
+	}
+	e.game = game.NewGame(append(opts, s.gameOpts...)...)
+	s.mu.Lock()
+	s.games[e.game.ID()] = e
+	s.mu.Unlock()
+	return state(e.game), nil
+}
+
+func (s *Server) JoinGame(ctx context.Context, req *farklepb.JoinGameRequest) (*farklepb.JoinGameResponse, error) {
+	e, err := s.get(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "a name is needed to join")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	g := e.game
+	if e.started {
+		return nil, status.Error(codes.FailedPrecondition, "game has started")
+	}
+	opts := []game.PlayerOpt{game.WithTeam(req.GetTeam())}
+	if id := req.GetPlayerId(); id != "" {
+		for _, player := range g.Players() {
+			if player.ID() == id {
+				return nil, status.Errorf(codes.AlreadyExists, "player %q has joined", id)
+			}
+		}
+		opts = append(opts, game.WithID(id))
+	}
+	g.Join(req.GetName(), opts...)
+	players := g.Players()
+	return &farklepb.JoinGameResponse{PlayerId: players[len(players)-1].ID(), State: state(g)}, nil
+}
+
+func (s *Server) StartGame(ctx context.Context, req *farklepb.GameRequest) (*farklepb.GameState, error) {
+	e, err := s.get(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.started {
+		return nil, status.Error(codes.FailedPrecondition, "game has started")
+	}
+	if err := e.game.Start(); err != nil {
+		return nil, convert(err)
+	}
+	e.started = true
+	return state(e.game), nil
+}
+
+func (s *Server) GetGame(ctx context.Context, req *farklepb.GameRequest) (*farklepb.GameState, error) {
+	e, err := s.get(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return state(e.game), nil
+}
+
+func (s *Server) Accept(ctx context.Context, req *farklepb.ActionRequest) (*farklepb.GameState, error) {
+	return s.act(req.GetGameId(), req.GetPlayerId(), (*game.Game).Accept)
+}
+
+func (s *Server) Reject(ctx context.Context, req *farklepb.ActionRequest) (*farklepb.GameState, error) {
+	return s.act(req.GetGameId(), req.GetPlayerId(), (*game.Game).Reject)
+}
+
+func (s *Server) Roll(ctx context.Context, req *farklepb.ActionRequest) (*farklepb.GameState, error) {
+	return s.act(req.GetGameId(), req.GetPlayerId(), (*game.Game).Roll)
+}
+
+func (s *Server) Keep(ctx context.Context, req *farklepb.KeepRequest) (*farklepb.GameState, error) {
+	dice := make([]int, len(req.GetDice()))
+	for i, die := range req.GetDice() {
+		dice[i] = int(die)
+	}
+	return s.act(req.GetGameId(), req.GetPlayerId(), func(g *game.Game) error { return g.Keep(dice...) })
+}
+
+func (s *Server) Bank(ctx context.Context, req *farklepb.ActionRequest) (*farklepb.GameState, error) {
+	return s.act(req.GetGameId(), req.GetPlayerId(), (*game.Game).Bank)
+}
+
+func (s *Server) Subscribe(req *farklepb.GameRequest, stream farklepb.Farkle_SubscribeServer) error {
+	e, err := s.get(req.GetGameId())
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	history := append([]game.Event(nil), e.events...)
+	over := e.game.Over()
+	events := make(chan game.Event, backlog)
+	if !over {
+		e.subscribers[events] = true
+	}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.subscribers[events] {
+			delete(e.subscribers, events)
+			close(events)
+		}
+	}()
+	for _, event := range history {
+		if err := stream.Send(message(event)); err != nil {
+			return err
+		}
+	}
+	if over {
+		return nil
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event, ok := <-events:
+			if !ok {
+				if e.ended() {
+					return nil
+				}
+				return status.Error(codes.ResourceExhausted, "fell too far behind the game")
+			}
+			if err := stream.Send(message(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ended reports whether the game is over
+func (e *entry) ended() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.game.Over()
+}
+
+// act performs an action for the player, who must be the current player
+func (s *Server) act(id, player string, action func(*game.Game) error) (*farklepb.GameState, error) {
+	e, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	g := e.game
+	if !e.started || g.Current().ID() != player {
+		return nil, convert(game.ErrNotYourTurn)
+	}
+	if err := action(g); err != nil {
+		return nil, convert(err)
+	}
+	return state(g), nil
+}
+
+func (s *Server) get(id string) (*entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.games[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no game %q", id)
+	}
+	return e, nil
+}
+
+// convert gives a game error its status code
+func convert(err error) error {
+	switch {
+	case errors.Is(err, game.ErrNotYourTurn):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, game.ErrInvalidKeep):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+}
+
+func NewServer(opts ...Opt) *Server {
+	s := &Server{games: make(map[string]*entry)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package rpc_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/rpc"
+	"github.com/ryannatesmith/farkle/rpc/farklepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dial(t *testing.T, server *rpc.Server) farklepb.FarkleClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	farklepb.RegisterFarkleServer(s, server)
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return farklepb.NewFarkleClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := dial(t, rpc.NewServer(rpc.WithGameOpts(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6).Roll))))
+	created, err := client.CreateGame(ctx, &farklepb.CreateGameRequest{Target: 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := created.GetId()
+	for _, name := range []string{"alice", "bob"} {
+		if _, err := client.JoinGame(ctx, &farklepb.JoinGameRequest{GameId: id, Name: name, PlayerId: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.JoinGame(ctx, &farklepb.JoinGameRequest{GameId: id, Name: "bob", PlayerId: "bob"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("joined twice: %v", err)
+	}
+	stream, err := client.Subscribe(ctx, &farklepb.GameRequest{GameId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartGame(ctx, &farklepb.GameRequest{GameId: id}); err != nil {
+		t.Fatal(err)
+	}
+	alice := &farklepb.ActionRequest{GameId: id, PlayerId: "alice"}
+	bob := &farklepb.ActionRequest{GameId: id, PlayerId: "bob"}
+	rolled, err := client.Roll(ctx, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{1, 1, 1, 5, 4, 2}, rolled.GetDice()); diff != "" {
+		t.Errorf("dice: +want -got\n%s", diff)
+	}
+	if _, err := client.Roll(ctx, bob); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("rolled out of turn: %v", err)
+	}
+	if _, err := client.Keep(ctx, &farklepb.KeepRequest{GameId: id, PlayerId: "alice", Dice: []int32{4}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("kept a non-scoring die: %v", err)
+	}
+	if _, err := client.Keep(ctx, &farklepb.KeepRequest{GameId: id, PlayerId: "alice", Dice: []int32{0, 1, 2, 3}}); err != nil {
+		t.Fatal(err)
+	}
+	banked, err := client.Bank(ctx, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if banked.GetState() != farklepb.State_STATE_AWAITING_DECISION || banked.GetOfferScore() != 350 {
+		t.Errorf("unexpected state after bank %v", banked)
+	}
+	if _, err := client.Reject(ctx, bob); err != nil {
+		t.Fatal(err)
+	}
+	over, err := client.Roll(ctx, bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if over.GetState() != farklepb.State_STATE_GAME_OVER || over.GetWinner() != 0 || over.GetPlayers()[0].GetScore() != 350 {
+		t.Errorf("unexpected state at the end %v", over)
+	}
+	var got []string
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, event.GetType())
+	}
+	want := []string{"joined", "joined", "started", "rolled", "kept", "banked", "rejected", "rolled", "farkle", "ended"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events: +want -got\n%s", diff)
+	}
+	if _, err := client.GetGame(ctx, &farklepb.GameRequest{GameId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("found a missing game: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Package session keeps a networked game going when players drop out. Each
+// player's seat is reserved for a grace period after they disconnect, and
+// they reclaim it with a reconnect token, getting the whole state of the
+// game back. Meanwhile a Policy decides what happens to their turns.
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+const (
+	defaultGrace = 2 * time.Minute
+	tokenLength  = 16
+)
+
+var (
+	ErrUnknownPlayer = errors.New("no seat for that player")
+	ErrUnknownToken  = errors.New("no seat for that token")
+	ErrSeatReleased  = errors.New("seat reservation has expired")
+)
+
+// Policy decides what happens to a disconnected player's turns
+type Policy int
+
+const (
+	// Pause holds the game on the player's turn until they reconnect, or
+	// their seat is released and the turn skipped
+	Pause Policy = iota
+	// Takeover has a computer player play their turns
+	Takeover
+	// Skip passes over their turns, abandoning any turn in progress
+	Skip
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Pause:
+		return "pause"
+	case Takeover:
+		return "takeover"
+	case Skip:
+		return "skip"
+	default:
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+}
+
+// Resync is everything a reconnecting player needs to pick the game up
+// again
+type Resync struct {
+	Player   string        `json:"player"`
+	Snapshot game.Snapshot `json:"snapshot"`
+}
+
+// seat is a player's place at the table
+type seat struct {
+	token     string
+	connected bool
+	since     time.Time
+	released  bool
+}
+
+type Opt func(*Table)
+
+// WithGrace sets how long a disconnected player's seat is reserved, two
+// minutes by default
+func WithGrace(grace time.Duration) Opt {
+	return func(t *Table) {
+		t.grace = grace
+	}
+}
+
+// WithPolicy sets what happens to a disconnected player's turns, Pause by
+// default
+func WithPolicy(policy Policy) Opt {
+	return func(t *Table) {
+		t.policy = policy
+	}
+}
+
+// WithBot sets the strategy that plays for disconnected players under
+// Takeover, the optimal strategy by default
+func WithBot(bot strategy.Strategy) Opt {
+	return func(t *Table) {
+		t.bot = bot
+	}
+}
+
+// WithClock sets the source of the current time, for tests
+func WithClock(now func() time.Time) Opt {
+	return func(t *Table) {
+		t.now = now
+	}
+}
+
+// Table reserves the seats of a game's players. The game itself is not
+// safe for concurrent use, so callers must not act on it during Tick.
+type Table struct {
+	mu     sync.Mutex
+	game   *game.Game
+	seats  map[string]*seat
+	grace  time.Duration
+	policy Policy
+	bot    strategy.Strategy
+	now    func() time.Time
+}
+
+// Game returns the game the table is for
+func (t *Table) Game() *game.Game {
+	return t.game
+}
+
+// Policy returns what happens to disconnected players' turns
+func (t *Table) Policy() Policy {
+	return t.policy
+}
+
+// Token returns the token the player reconnects with
+func (t *Table) Token(id string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.seats[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownPlayer, id)
+	}
+	return s.token, nil
+}
+
+// Connected reports whether the player is connected
+func (t *Table) Connected(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.seats[id]
+	return ok && s.connected
+}
+
+// Remaining returns how long the player's seat stays reserved, zero when
+// they are connected or it has been released
+func (t *Table) Remaining(id string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.seats[id]
+	if !ok || s.connected || s.released {
+		return 0
+	}
+	return max(s.since.Add(t.grace).Sub(t.now()), 0)
+}
+
+// Disconnect reserves the player's seat for the grace period
+func (t *Table) Disconnect(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.seats[id]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPlayer, id)
+	}
+	if s.connected {
+		s.connected, s.since = false, t.now()
+	}
+	return nil
+}
+
+// Reconnect returns the player to the seat the token reserves, with the
+// state of the game to resync them
+func (t *Table) Reconnect(token string) (Resync, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, s := range t.seats {
+		if s.token != token {
+			continue
+		}
+		if t.expire(s) {
+			return Resync{}, fmt.Errorf("%w for player %q", ErrSeatReleased, id)
+		}
+		s.connected = true
+		return Resync{Player: id, Snapshot: t.game.Snapshot()}, nil
+	}
+	return Resync{}, ErrUnknownToken
+}
+
+// Tick releases seats whose grace period is over, then plays out the
+// turns of disconnected players by the policy until it is a connected
+// player's turn, the game is paused or each player has had a turn. Call
+// it after every action and regularly while anyone is disconnected.
+func (t *Table) Tick() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, s := range t.seats {
+		t.expire(s)
+	}
+	for range t.game.Players() {
+		if t.game.Over() {
+			return nil
+		}
+		s := t.seats[t.game.Current().ID()]
+		if s.connected {
+			return nil
+		}
+		switch {
+		case t.policy == Takeover:
+			if err := strategy.TakeTurn(t.game, t.bot); err != nil {
+				return err
+			}
+		case t.policy == Skip, s.released:
+			if err := t.game.Skip(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// expire releases the seat once its grace period is over, reporting
+// whether it has been released
+func (t *Table) expire(s *seat) bool {
+	if !s.connected && !s.released && !t.now().Before(s.since.Add(t.grace)) {
+		s.released = true
+	}
+	return s.released
+}
+
+// New reserves a seat for each player of a started game, all of them
+// connected
+func New(g *game.Game, opts ...Opt) *Table {
+	t := &Table{game: g, seats: make(map[string]*seat), grace: defaultGrace, now: time.Now}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.bot == nil && t.policy == Takeover {
+		t.bot = strategy.Optimal()
+	}
+	for _, player := range g.Players() {
+		t.seats[player.ID()] = &seat{token: newToken(), connected: true}
+	}
+	return t
+}
+
+func newToken() string {
+	b := make([]byte, tokenLength)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,145 @@
+package session_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/session"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func setup(t *testing.T, opts ...session.Opt) (*session.Table, *time.Time) {
+	t.Helper()
+	g := game.NewGame(game.WithRandom(farkletest.Cycle(1, 1, 1, 5, 4, 2)))
+	g.Join("one")
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts = append(opts, session.WithGrace(time.Minute), session.WithClock(func() time.Time { return now }))
+	return session.New(g, opts...), &now
+}
+
+func TestTable_Pause(t *testing.T) {
+	t.Parallel()
+	table, now := setup(t)
+	g := table.Game()
+	one := g.Current()
+	if err := table.Disconnect(one.ID()); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(30 * time.Second)
+	if err := table.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Current() != one {
+		t.Fatal("game moved on during the grace period")
+	}
+	if got := table.Remaining(one.ID()); got != 30*time.Second {
+		t.Errorf("remaining: got %v, want 30s", got)
+	}
+	token, err := table.Token(one.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resync, err := table.Reconnect(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := session.Resync{Player: one.ID(), Snapshot: g.Snapshot()}
+	if diff := cmp.Diff(want, resync); diff != "" {
+		t.Errorf("resync: +want -got\n%s", diff)
+	}
+	if !table.Connected(one.ID()) {
+		t.Error("not connected after reconnecting")
+	}
+
+	if err := table.Disconnect(one.ID()); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(time.Minute)
+	if err := table.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Current() == one {
+		t.Error("turn not skipped once the seat was released")
+	}
+	if _, err := table.Reconnect(token); !errors.Is(err, session.ErrSeatReleased) {
+		t.Errorf("reconnect after release: got %v, want %v", err, session.ErrSeatReleased)
+	}
+}
+
+func TestTable_Skip(t *testing.T) {
+	t.Parallel()
+	table, _ := setup(t, session.WithPolicy(session.Skip))
+	g := table.Game()
+	one := g.Current()
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Disconnect(one.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Current() == one {
+		t.Fatal("turn not skipped")
+	}
+	if one.Score() != 0 || one.Active() {
+		t.Errorf("skipped player scored %d, active %t", one.Score(), one.Active())
+	}
+	if err := g.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Keep(0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Bank(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Current() == one {
+		t.Error("second turn not skipped")
+	}
+}
+
+func TestTable_Takeover(t *testing.T) {
+	t.Parallel()
+	table, _ := setup(t, session.WithPolicy(session.Takeover), session.WithBot(strategy.Threshold("bot", 300, 3)))
+	g := table.Game()
+	one := g.Current()
+	if err := table.Disconnect(one.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Current() == one {
+		t.Fatal("turn not taken over")
+	}
+	if got := one.Score(); got != 350 {
+		t.Errorf("score: got %d, want 350", got)
+	}
+}
+
+func TestTable_UnknownToken(t *testing.T) {
+	t.Parallel()
+	table, _ := setup(t)
+	if _, err := table.Reconnect("nope"); !errors.Is(err, session.ErrUnknownToken) {
+		t.Errorf("got %v, want %v", err, session.ErrUnknownToken)
+	}
+	if err := table.Disconnect("nope"); !errors.Is(err, session.ErrUnknownPlayer) {
+		t.Errorf("got %v, want %v", err, session.ErrUnknownPlayer)
+	}
+}
@@ -0,0 +1,112 @@
+// Package spectate lets people watch a game without a seat in it. A feed
+// follows the game's events and keeps its own copy of the game, so
+// spectators only ever see snapshots and can never act. Snapshots can be
+// held back by a delay so that spectators cannot coach the players.
+package spectate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+type Opt func(*Feed)
+
+// WithDelay holds back each snapshot until it is at least d old
+func WithDelay(d time.Duration) Opt {
+	return func(f *Feed) {
+		f.delay = d
+	}
+}
+
+// WithGame sets the ID of the game followed, when not started by Follow
+func WithGame(id string) Opt {
+	return func(f *Feed) {
+		f.replica = game.NewGame(game.WithGameID(id))
+	}
+}
+
+// WithClock sets the source of the current time, for tests
+func WithClock(now func() time.Time) Opt {
+	return func(f *Feed) {
+		f.now = now
+	}
+}
+
+// frame is a snapshot and when it was taken
+type frame struct {
+	at       time.Time
+	snapshot game.Snapshot
+}
+
+// Feed follows a game for spectators. Use Follow to start the game, or
+// Listen with game.WithListener and WithGame.
+type Feed struct {
+	mu      sync.Mutex
+	delay   time.Duration
+	now     func() time.Time
+	replica *game.Game
+	frames  []frame
+	err     error
+}
+
+// Listen applies an event to the feed's copy of the game and takes a
+// snapshot of it
+func (f *Feed) Listen(event game.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return
+	}
+	if err := f.replica.ApplyEvent(event); err != nil {
+		f.err = err
+		return
+	}
+	f.frames = append(f.frames, frame{at: f.now(), snapshot: f.replica.Snapshot()})
+}
+
+// View returns the latest snapshot old enough for spectators to see, and
+// false when there is none yet
+func (f *Feed) View() (game.Snapshot, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cutoff := f.now().Add(-f.delay)
+	visible := -1
+	for i, frame := range f.frames {
+		if frame.at.After(cutoff) {
+			break
+		}
+		visible = i
+	}
+	if visible < 0 {
+		return game.Snapshot{}, false
+	}
+	// earlier frames can never be seen again
+	f.frames = f.frames[visible:]
+	return f.frames[0].snapshot, true
+}
+
+// Err returns the error that stopped the feed following the game
+func (f *Feed) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Follow starts a game followed by the feed
+func (f *Feed) Follow(opts ...game.GameOpt) *game.Game {
+	g := game.NewGame(append(opts, game.WithListener(f.Listen))...)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replica = game.NewGame(game.WithGameID(g.ID()))
+	return g
+}
+
+func NewFeed(opts ...Opt) *Feed {
+	f := &Feed{now: time.Now, replica: game.NewGame()}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
@@ -0,0 +1,77 @@
+package spectate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/spectate"
+)
+
+func TestFeed(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feed := spectate.NewFeed(spectate.WithDelay(time.Minute), spectate.WithClock(func() time.Time { return now }))
+	g := feed.Follow(game.WithRandom(farkletest.Cycle(1, 1, 1, 5, 4, 2, 4, 3, 2, 3, 4, 6)), game.WithTarget(300))
+	g.Join("one")
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := feed.View(); ok {
+		t.Error("snapshot seen before the delay")
+	}
+	now = now.Add(time.Minute)
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	view, ok := feed.View()
+	if !ok {
+		t.Fatal("no snapshot after the delay")
+	}
+	if view.State != game.AwaitingRoll || view.Dice != nil {
+		t.Errorf("saw the roll before the delay: %+v", view)
+	}
+	now = now.Add(time.Minute)
+	if diff := cmp.Diff(g.Snapshot(), mustView(t, feed)); diff != "" {
+		t.Errorf("snapshot: +want -got\n%s", diff)
+	}
+	if err := g.Keep(0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Bank(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Minute)
+	want := game.Snapshot{
+		ID:         g.ID(),
+		State:      game.GameOver,
+		Target:     300,
+		Players:    []game.PlayerSnapshot{{ID: g.Players()[0].ID(), Name: "one", Score: 350, Turns: 1, Target: 300}, {ID: g.Players()[1].ID(), Name: "two", Turns: 1, Target: 300}},
+		FinalRound: true,
+		Winner:     0,
+	}
+	if diff := cmp.Diff(want, mustView(t, feed)); diff != "" {
+		t.Errorf("snapshot: +want -got\n%s", diff)
+	}
+	if err := feed.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func mustView(t *testing.T, feed *spectate.Feed) game.Snapshot {
+	t.Helper()
+	view, ok := feed.View()
+	if !ok {
+		t.Fatal("no snapshot")
+	}
+	return view
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"sort"
+)
+
+// Profile holds the lifetime figures for a single player
+type Profile struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Games       int            `json:"games"`
+	Wins        int            `json:"wins"`
+	Losses      int            `json:"losses"`
+	Turns       int            `json:"turns"`
+	TotalScore  uint64         `json:"totalScore"`
+	HighestTurn uint32         `json:"highestTurn"`
+	HotDice     int            `json:"hotDice"`
+	Rolls       map[int]int    `json:"rolls"`
+	Farkles     map[int]int    `json:"farkles"`
+	Banks       map[int]int    `json:"banks"`
+	Combos      map[string]int `json:"combos"`
+}
+
+// Combo is a scoring combination and the number of times it was kept
+type Combo struct {
+	Dice  string
+	Count int
+}
+
+// AverageTurn returns the mean score of every turn played
+func (p *Profile) AverageTurn() float64 {
+	if p.Turns == 0 {
+		return 0
+	}
+	return float64(p.TotalScore) / float64(p.Turns)
+}
+
+// FarkleRate returns the fraction of rolls with the given number of dice
+// that scored nothing
+func (p *Profile) FarkleRate(dice int) float64 {
+	if p.Rolls[dice] == 0 {
+		return 0
+	}
+	return float64(p.Farkles[dice]) / float64(p.Rolls[dice])
+}
+
+// TopCombos returns the n most commonly kept scoring combinations
+func (p *Profile) TopCombos(n int) []Combo {
+	ret := make([]Combo, 0, len(p.Combos))
+	for dice, count := range p.Combos {
+		ret = append(ret, Combo{Dice: dice, Count: count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count == ret[j].Count {
+			return ret[i].Dice < ret[j].Dice
+		}
+		return ret[i].Count > ret[j].Count
+	})
+	if len(ret) > n {
+		ret = ret[:n]
+	}
+	return ret
+}
+
+func newProfile(id string) *Profile {
+	return &Profile{
+		ID:      id,
+		Rolls:   make(map[int]int),
+		Farkles: make(map[int]int),
+		Banks:   make(map[int]int),
+		Combos:  make(map[string]int),
+	}
+}
@@ -0,0 +1,89 @@
+// Package stats collects lifetime figures for players across games
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+// ErrNotOver is returned when recording a game that is still being played
+var ErrNotOver = errors.New("game is not over")
+
+type Stats struct {
+	store Store
+}
+
+// Record adds every player's turns from a finished game to their profile.
+// In a team game each member of the winning team is credited with a win.
+func (s *Stats) Record(g *game.Game) error {
+	if !g.Over() {
+		return ErrNotOver
+	}
+	for _, player := range g.Players() {
+		profile, err := s.Profile(player.ID())
+		if err != nil {
+			return err
+		}
+		profile.Name = player.Name()
+		profile.Games++
+		if g.Compare(player, g.Winner()) == 0 {
+			profile.Wins++
+		} else {
+			profile.Losses++
+		}
+		for _, turn := range player.Turns() {
+			record(profile, turn)
+		}
+		if err := s.store.Save(profile); err != nil {
+			return fmt.Errorf("saving profile %q: %w", player.ID(), err)
+		}
+	}
+	return nil
+}
+
+// Profile returns the profile for the given player identity, or an empty
+// profile if the player has not been seen before
+func (s *Stats) Profile(id string) (*Profile, error) {
+	profile, err := s.store.Load(id)
+	if errors.Is(err, ErrNotFound) {
+		return newProfile(id), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading profile %q: %w", id, err)
+	}
+	return profile, nil
+}
+
+func record(profile *Profile, turn *game.Turn) {
+	profile.Turns++
+	profile.TotalScore += uint64(turn.Result())
+	profile.HighestTurn = max(profile.HighestTurn, turn.Result())
+	profile.HotDice += turn.HotDice()
+	for _, roll := range turn.Thrown() {
+		profile.Rolls[len(roll)]++
+	}
+	if thrown := turn.Thrown(); turn.Farkle() && len(thrown) > 0 {
+		profile.Farkles[len(thrown[len(thrown)-1])]++
+	}
+	if turn.Banked() {
+		profile.Banks[turn.Available()]++
+	}
+	for _, roll := range turn.Rolls() {
+		profile.Combos[combo(roll)]++
+	}
+}
+
+func combo(roll game.Roll) string {
+	dice := make([]string, len(roll))
+	for i, d := range roll {
+		dice[i] = fmt.Sprint(d)
+	}
+	return strings.Join(dice, " ")
+}
+
+func New(store Store) *Stats {
+	return &Stats{store: store}
+}
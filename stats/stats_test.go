@@ -0,0 +1,144 @@
+package stats_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/stats"
+)
+
+func TestStats_Record(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		store func(t *testing.T) stats.Store
+	}
+	for _, c := range []testCase{
+		{
+			name: "memory",
+			store: func(t *testing.T) stats.Store {
+				return stats.NewMemoryStore()
+			},
+		},
+		{
+			name: "file",
+			store: func(t *testing.T) stats.Store {
+				store, err := stats.NewFileStore(t.TempDir())
+				if err != nil {
+					t.Fatal(err)
+				}
+				return store
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			s := stats.New(c.store(t))
+			for range 2 {
+				g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2, 2, 3, 4, 6, 4, 3).Roll), game.WithTarget(300))
+				g.Join("alice", game.WithID("a"))
+				g.Join("bob", game.WithID("b"))
+				if err := g.Start(); err != nil {
+					t.Fatal(err)
+				}
+				play(t, g)
+				if err := s.Record(g); err != nil {
+					t.Fatal(err)
+				}
+			}
+			alice, err := s.Profile("a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &stats.Profile{
+				ID:          "a",
+				Name:        "alice",
+				Games:       2,
+				Wins:        2,
+				Turns:       2,
+				TotalScore:  700,
+				HighestTurn: 350,
+				Rolls:       map[int]int{6: 2},
+				Farkles:     map[int]int{},
+				Banks:       map[int]int{2: 2},
+				Combos:      map[string]int{"1 1 1": 2, "5": 2},
+			}
+			if diff := cmp.Diff(want, alice); diff != "" {
+				t.Error("+want -got", diff)
+			}
+			bob, err := s.Profile("b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bob.Losses != 2 || bob.FarkleRate(6) != 1 {
+				t.Errorf("unexpected profile for bob: %+v", bob)
+			}
+		})
+	}
+}
+
+func TestStats_RecordUnfinished(t *testing.T) {
+	t.Parallel()
+	s := stats.New(stats.NewMemoryStore())
+	g := game.NewGame(game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll))
+	g.Join("alice", game.WithID("a"))
+	g.Join("bob", game.WithID("b"))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Record(g); !errors.Is(err, stats.ErrNotOver) {
+		t.Errorf("error: +want -got\n\t+%v\n\t-%v", stats.ErrNotOver, err)
+	}
+	if profile, err := s.Profile("a"); err != nil || profile.Games != 0 {
+		t.Errorf("recorded an unfinished game: %+v, %v", profile, err)
+	}
+}
+
+// play takes alice past the target and has bob farkle, ending the game
+func play(t *testing.T, g *game.Game) {
+	t.Helper()
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Keep(0, 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Bank(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Roll(); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Over() {
+		t.Fatal("game not over")
+	}
+}
+
+func TestFileStore_IDs(t *testing.T) {
+	t.Parallel()
+	store, err := stats.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{"a/b", "c/b", "../b", "b"}
+	for i, id := range ids {
+		if err := store.Save(&stats.Profile{ID: id, Games: i + 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, id := range ids {
+		profile, err := store.Load(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile.ID != id || profile.Games != i+1 {
+			t.Errorf("%s: loaded %s with %d games", id, profile.ID, profile.Games)
+		}
+	}
+}
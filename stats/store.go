@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrNotFound = errors.New("profile not found")
+
+// Store persists player profiles between runs
+type Store interface {
+	Load(id string) (*Profile, error)
+	Save(profile *Profile) error
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	profiles map[string][]byte
+}
+
+func (m *memoryStore) Load(id string) (*Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.profiles[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return decode(b)
+}
+
+func (m *memoryStore) Save(profile *Profile) error {
+	b, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.profiles[profile.ID] = b
+	return nil
+}
+
+// NewMemoryStore returns a store that keeps profiles for the life of the process
+func NewMemoryStore() Store {
+	return &memoryStore{profiles: make(map[string][]byte)}
+}
+
+type fileStore struct {
+	dir string
+}
+
+func (f *fileStore) Load(id string) (*Profile, error) {
+	b, err := os.ReadFile(f.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decode(b)
+}
+
+func (f *fileStore) Save(profile *Profile) error {
+	b, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	tmp := f.path(profile.ID) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(profile.ID))
+}
+
+// path returns the file holding the player's profile, their ID escaped so
+// that no two players share a file
+func (f *fileStore) path(id string) string {
+	return filepath.Join(f.dir, url.PathEscape(id)+".json")
+}
+
+// NewFileStore returns a store that keeps one JSON file per player in dir
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func decode(b []byte) (*Profile, error) {
+	profile := newProfile("")
+	if err := json.Unmarshal(b, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
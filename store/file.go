@@ -0,0 +1,157 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/stats"
+)
+
+const (
+	logExt = ".jsonl"
+)
+
+// file keeps each game's log as an append-only file of JSON lines, and
+// each profile as a JSON file
+type file struct {
+	mu       sync.Mutex
+	dir      string
+	profiles stats.Store
+}
+
+func (f *file) Append(id string, events ...game.Event) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	log, err := os.OpenFile(f.path(id), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	end, err := repair(log)
+	if err != nil {
+		log.Close()
+		return err
+	}
+	if _, err := log.WriteAt(buf.Bytes(), end); err != nil {
+		log.Close()
+		return err
+	}
+	if err := log.Sync(); err != nil {
+		log.Close()
+		return err
+	}
+	return log.Close()
+}
+
+// repair cuts off a final line left short by a crash while appending, so
+// that the next append starts on a line of its own, and returns the length
+// of the log that is left
+func repair(log *os.File) (int64, error) {
+	info, err := log.Stat()
+	if err != nil {
+		return 0, err
+	}
+	end := info.Size()
+	buf := make([]byte, 4096)
+	for off := end; off > 0; {
+		n := min(off, int64(len(buf)))
+		off -= n
+		if _, err := log.ReadAt(buf[:n], off); err != nil {
+			return 0, err
+		}
+		i := bytes.LastIndexByte(buf[:n], '\n')
+		if i < 0 {
+			continue
+		}
+		if last := off + int64(i) + 1; last < end {
+			return last, log.Truncate(last)
+		}
+		return end, nil
+	}
+	return 0, log.Truncate(0)
+}
+
+// Events reads a game's log. A final line cut short by a crash while
+// appending is ignored.
+func (f *file) Events(id string) ([]game.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := os.ReadFile(f.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if i := bytes.LastIndexByte(b, '\n'); i < len(b)-1 {
+		b = b[:i+1]
+	}
+	events := make([]game.Event, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; scanner.Scan(); line++ {
+		var event game.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", id, line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+func (f *file) Games() ([]string, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), logExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if id, err := url.PathUnescape(name); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (f *file) Profiles() stats.Store {
+	return f.profiles
+}
+
+// path returns the file holding the game's log, its ID escaped so that no
+// two games share a file
+func (f *file) path(id string) string {
+	return filepath.Join(f.dir, url.PathEscape(id)+logExt)
+}
+
+// NewFile returns a store kept in dir, needing no outside database. Game
+// logs are written to dir and profiles to dir/profiles.
+func NewFile(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	profiles, err := stats.NewFileStore(filepath.Join(dir, "profiles"))
+	if err != nil {
+		return nil, err
+	}
+	return &file{dir: dir, profiles: profiles}, nil
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/stats"
+)
+
+type memory struct {
+	mu       sync.Mutex
+	games    map[string][]game.Event
+	profiles stats.Store
+}
+
+func (m *memory) Append(id string, events ...game.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.games[id] = append(m.games[id], events...)
+	return nil
+}
+
+func (m *memory) Events(id string) ([]game.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events, ok := m.games[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	return append([]game.Event(nil), events...), nil
+}
+
+func (m *memory) Games() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.games))
+	for id := range m.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *memory) Profiles() stats.Store {
+	return m.profiles
+}
+
+// NewMemory returns a store that keeps everything for the life of the process
+func NewMemory() Store {
+	return &memory{games: make(map[string][]game.Event), profiles: stats.NewMemoryStore()}
+}
@@ -0,0 +1,76 @@
+// Package store saves games as logs of their events, along with player
+// profiles, so play can resume after a restart
+package store
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/stats"
+)
+
+var ErrNotFound = errors.New("game not found")
+
+type Store interface {
+	// Append adds events to the end of a game's log
+	Append(id string, events ...game.Event) error
+	// Events returns a game's log
+	Events(id string) ([]game.Event, error)
+	// Games returns the IDs of every game in the store
+	Games() ([]string, error)
+	// Profiles returns the store of player profiles
+	Profiles() stats.Store
+}
+
+// Recorder appends every event of a game to a store. Use Listen with
+// game.WithListener.
+type Recorder struct {
+	mu    sync.Mutex
+	store Store
+	id    string
+	err   error
+}
+
+func (r *Recorder) Listen(event game.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store.Append(r.id, event); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the first error appending an event
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func NewRecorder(store Store, id string) *Recorder {
+	return &Recorder{store: store, id: id}
+}
+
+// New starts a game whose events are recorded in the store
+func New(store Store, opts ...game.GameOpt) (*game.Game, *Recorder) {
+	recorder := &Recorder{store: store}
+	g := game.NewGame(append(opts, game.WithListener(recorder.Listen))...)
+	recorder.id = g.ID()
+	return g, recorder
+}
+
+// Resume replays a game from its log in the store. Events that follow
+// are recorded in the store.
+func Resume(store Store, id string, opts ...game.GameOpt) (*game.Game, *Recorder, error) {
+	events, err := store.Events(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	recorder := NewRecorder(store, id)
+	opts = append(opts, game.WithGameID(id), game.WithListener(recorder.Listen))
+	g, err := game.Replay(events, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, recorder, nil
+}
@@ -0,0 +1,173 @@
+package store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/farkletest"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/stats"
+	"github.com/ryannatesmith/farkle/store"
+)
+
+func TestResume(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		store func(t *testing.T) func() store.Store
+	}
+	for _, c := range []testCase{
+		{
+			name: "memory",
+			store: func(t *testing.T) func() store.Store {
+				s := store.NewMemory()
+				return func() store.Store { return s }
+			},
+		},
+		{
+			name: "file",
+			store: func(t *testing.T) func() store.Store {
+				dir := t.TempDir()
+				return func() store.Store {
+					s, err := store.NewFile(dir)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return s
+				}
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			open := c.store(t)
+			g, recorder := store.New(open(), game.WithRandom(farkletest.NewDice(1, 1, 1, 5, 4, 2).Roll), game.WithTarget(300))
+			g.Join("alice", game.WithID("a"))
+			g.Join("bob", game.WithID("b"))
+			for _, step := range []func() error{
+				g.Start,
+				g.Roll,
+				func() error { return g.Keep(0, 1, 2, 3) },
+			} {
+				if err := step(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := recorder.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			s := open()
+			ids, err := s.Games()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != 1 || ids[0] != g.ID() {
+				t.Fatalf("unexpected games %v", ids)
+			}
+			resumed, recorder, err := store.Resume(s, g.ID(), game.WithRandom(farkletest.NewDice(2, 3, 4, 6, 4, 3).Roll))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resumed.Current().Turn(); got != 350 {
+				t.Errorf("turn: +want -got\n\t+%d\n\t-%d", 350, got)
+			}
+			for _, step := range []func() error{resumed.Bank, resumed.Reject, resumed.Roll} {
+				if err := step(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			events, err := open().Events(g.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 10 || !resumed.Over() || recorder.Err() != nil {
+				t.Errorf("unexpected events %v, %v", events, recorder.Err())
+			}
+
+			if err := stats.New(s.Profiles()).Record(resumed); err != nil {
+				t.Fatal(err)
+			}
+			profile, err := stats.New(open().Profiles()).Profile("a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if profile.Games != 1 {
+				t.Errorf("profile should have survived the restart: %+v", profile)
+			}
+			if _, err := s.Events("missing"); !errors.Is(err, store.ErrNotFound) {
+				t.Error("unexpected error", err)
+			}
+		})
+	}
+}
+
+func TestFile_TruncatedLog(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := store.NewFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append("g", game.Event{Type: game.EventJoined, Player: "a", Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	log, err := os.OpenFile(filepath.Join(dir, "g.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.WriteString(`{"type":"joi`); err != nil {
+		t.Fatal(err)
+	}
+	log.Close()
+	events, err := s.Events("g")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Errorf("unexpected events %v", events)
+	}
+	if err := s.Append("g", game.Event{Type: game.EventJoined, Player: "b", Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	events, err = s.Events("g")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Player != "b" {
+		t.Errorf("unexpected events after appending %v", events)
+	}
+}
+
+func TestFile_IDs(t *testing.T) {
+	t.Parallel()
+	s, err := store.NewFile(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{"x/y", "z/y", "../y", "y"}
+	for _, id := range ids {
+		if err := s.Append(id, game.Event{Type: game.EventJoined, Player: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range ids {
+		events, err := s.Events(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].Player != id {
+			t.Errorf("%s: unexpected events %v", id, events)
+		}
+	}
+	got, err := s.Games()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"../y", "x/y", "y", "z/y"}, got); diff != "" {
+		t.Errorf("games: +want -got\n%s", diff)
+	}
+}
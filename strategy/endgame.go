@@ -0,0 +1,243 @@
+package strategy
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	// rollouts is the number of games played out to estimate a chance of winning
+	rollouts = 400
+	// utilityStep is the spacing of the banked scores a chance of winning
+	// is estimated for, those between being interpolated
+	utilityStep = 250
+	// margin is how far past the target and the leader a banked score is
+	// taken to be as good as any higher score
+	margin          = 3_000
+	maxRolloutTurns = 1_000
+)
+
+// position is a game between turns, with next to play
+type position struct {
+	scores []uint32
+	next   int
+	target uint32
+	final  bool
+	last   int
+}
+
+// viewed returns the position of a view, the viewer being next to play
+func viewed(view View) position {
+	p := position{scores: append([]uint32{view.Score}, view.Opponents...), target: view.Target, final: view.FinalRound}
+	if p.final {
+		p.last = (view.Remaining + 1) % len(p.scores)
+	}
+	return p
+}
+
+// bank returns the position after the seat banks score
+func (p position) bank(seat int, score uint32) position {
+	p.scores = slices.Clone(p.scores)
+	p.scores[seat] += score
+	if !p.final && p.scores[seat] >= p.target {
+		p.final, p.last = true, seat
+	}
+	p.next = (seat + 1) % len(p.scores)
+	return p
+}
+
+// winner returns the seat with the highest score, the earliest winning a tie
+func (p position) winner() int {
+	winner := 0
+	for seat, score := range p.scores {
+		if score > p.scores[winner] {
+			winner = seat
+		}
+	}
+	return winner
+}
+
+// model plays games out a turn at a time, sampling each turn's score
+// rather than rolling dice. Turns are played to maximise their expected
+// score, or in the final round to beat the leader.
+type model struct {
+	solver     *Solver
+	cumulative []float64
+}
+
+func (m *model) rollout(r *rand.Rand, p position) int {
+	for range maxRolloutTurns {
+		if p.final && p.next == p.last {
+			break
+		}
+		p = p.bank(p.next, m.turn(r, p, 0, startDice, m.cumulative))
+	}
+	return p.winner()
+}
+
+// turn samples the score the next player banks, continuing a turn at
+// score with dice to roll. Outside the final round the score is drawn from
+// the cumulative chances of each final turn score.
+func (m *model) turn(r *rand.Rand, p position, score uint32, dice int, cumulative []float64) uint32 {
+	if p.final {
+		leader := p.scores[p.winner()]
+		need := leader + step - min(p.scores[p.next], leader)
+		if r.Float64() < m.solver.Reach(score, dice, need) {
+			return need
+		}
+		return 0
+	}
+	i := sort.SearchFloat64s(cumulative, r.Float64())
+	return uint32(min(i, len(cumulative)-1) * step)
+}
+
+// chances estimates each seat's chance of winning when the next player
+// continues a turn at score with dice to roll
+func (m *model) chances(p position, score uint32, dice int) []float64 {
+	r := rand.New(rand.NewPCG(1, uint64(len(p.scores))))
+	cumulative := accumulate(m.solver.Outcomes(score, dice))
+	ret := make([]float64, len(p.scores))
+	for range rollouts {
+		banked := m.turn(r, p, score, dice, cumulative)
+		ret[m.rollout(r, p.bank(p.next, banked))]++
+	}
+	for i := range ret {
+		ret[i] /= rollouts
+	}
+	return ret
+}
+
+// utility returns the chance of the next player winning by banking each
+// score, by score step, up to the score beyond which it barely improves
+func (m *model) utility(p position) []float64 {
+	enough := max(p.target, p.scores[p.winner()]) + margin - min(p.scores[p.next], p.target)
+	var coarse []float64
+	for banked := uint32(0); banked <= min(enough, horizon); banked += utilityStep {
+		r := rand.New(rand.NewPCG(1, uint64(len(p.scores))))
+		var wins float64
+		for range rollouts {
+			if m.rollout(r, p.bank(p.next, banked)) == p.next {
+				wins++
+			}
+		}
+		coarse = append(coarse, wins/rollouts)
+		if wins == rollouts {
+			break
+		}
+	}
+	ret := make([]float64, 0, len(coarse)*utilityStep/step)
+	for i, chance := range coarse {
+		next := chance
+		if i+1 < len(coarse) {
+			next = coarse[i+1]
+		}
+		for j := 0; j < utilityStep/step; j++ {
+			ret = append(ret, chance+(next-chance)*float64(j*step)/utilityStep)
+		}
+	}
+	return ret
+}
+
+func accumulate(chances []float64) []float64 {
+	ret := make([]float64, len(chances))
+	var sum float64
+	for i, chance := range chances {
+		sum += chance
+		ret[i] = sum
+	}
+	return ret
+}
+
+func newModel(solver *Solver) *model {
+	return &model{solver: solver, cumulative: accumulate(solver.Outcomes(0, startDice))}
+}
+
+type endgame struct {
+	model *model
+	mu    sync.Mutex
+	key   string
+	table *table
+}
+
+func (e *endgame) Name() string {
+	return "endgame"
+}
+
+func (e *endgame) Accept(view View) bool {
+	t := e.solve(view)
+	return t.roll(view.Turn, view.Available) > t.roll(0, startDice)
+}
+
+func (e *endgame) Keep(view View) []int {
+	option, _ := e.solve(view).keep(view.Turn, view.Roll)
+	return option.Dice
+}
+
+func (e *endgame) Bank(view View) bool {
+	t := e.solve(view)
+	return t.utility(view.Turn) >= t.roll(view.Turn, view.Available)
+}
+
+// solve values every point of the turn by the chance of winning after
+// banking there, reusing the last table while the scores are unchanged
+func (e *endgame) solve(view View) *table {
+	p := viewed(view)
+	key := fmt.Sprint(p)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.table != nil && e.key == key {
+		return e.table
+	}
+	chances := e.model.utility(p)
+	limit := uint32(len(chances)-1) * step
+	e.key = key
+	e.table = e.model.solver.solve(limit, func(score uint32) float64 {
+		return chances[min(int(score/step), len(chances)-1)]
+	})
+	return e.table
+}
+
+// Endgame plays to maximise its chance of winning the game, taking every
+// player's score and the turn order into account
+func Endgame() Strategy {
+	return &endgame{model: newModel(solver())}
+}
+
+// WinChances estimates each player's chance of winning from this point in
+// the game, for display to spectators. Each player is placed by their
+// standing, moved up by any target handicap so that all race to the same
+// target.
+func WinChances(g *game.Game) []float64 {
+	players := g.Players()
+	ret := make([]float64, len(players))
+	if len(players) == 0 {
+		return ret
+	}
+	if g.Over() {
+		for i, player := range players {
+			if player == g.Winner() {
+				ret[i] = 1
+			}
+		}
+		return ret
+	}
+	p := position{scores: make([]uint32, len(players)), next: Seat(g), target: g.Target()}
+	for i, player := range players {
+		p.scores[i] = g.Standing(player) + g.Target() - g.TargetFor(player)
+	}
+	p.last, p.final = g.FinalRound()
+	score, dice := uint32(0), startDice
+	if turn := players[p.next].Current(); turn != nil {
+		score, dice = turn.Result(), turn.Available()
+		if turn.State() == game.AwaitingKeep {
+			option, _ := solver().Keep(score, turn.Dice())
+			score, dice = score+option.Score, left(len(turn.Dice()), len(option.Dice))
+		}
+	}
+	return newModel(solver()).chances(p, score, dice)
+}
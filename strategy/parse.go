@@ -0,0 +1,39 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse returns the strategy described by spec, a kind followed by its
+// colon separated parameters:
+//
+//	threshold:<bank at score>:<bank below dice>
+//	optimal
+//	endgame
+func Parse(spec string) (Strategy, error) {
+	kind, params, _ := strings.Cut(spec, ":")
+	args := strings.Split(params, ":")
+	switch kind {
+	case "threshold":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%q: threshold takes a score and a number of dice", spec)
+		}
+		score, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", spec, err)
+		}
+		dice, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", spec, err)
+		}
+		return Threshold(spec, uint32(score), dice), nil
+	case "optimal":
+		return Optimal(), nil
+	case "endgame":
+		return Endgame(), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", spec)
+	}
+}
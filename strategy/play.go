@@ -0,0 +1,102 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	maxTurns = 10_000
+)
+
+var ErrTooLong = errors.New("game did not finish")
+
+// Play runs a game to the end, each player played by the strategy at the
+// index they joined at
+func Play(g *game.Game, bots ...Strategy) error {
+	players := g.Players()
+	if len(bots) != len(players) {
+		return fmt.Errorf("%d strategies for %d players", len(bots), len(players))
+	}
+	// starting a team game can change the seating
+	playing := make(map[*game.Player]Strategy, len(players))
+	for i, player := range players {
+		playing[player] = bots[i]
+	}
+	if err := g.Start(); err != nil {
+		return err
+	}
+	for turns := 0; !g.Over(); turns++ {
+		if turns >= maxTurns {
+			return ErrTooLong
+		}
+		if err := TakeTurn(g, playing[g.Current()]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TakeTurn plays the rest of the current player's turn with the strategy,
+// from wherever the turn has got to
+func TakeTurn(g *game.Game, bot Strategy) error {
+	player := g.Current()
+	if !player.Active() {
+		view := NewView(g)
+		view.Available, view.Turn = g.Offer()
+		decide := g.Reject
+		if view.Available > 0 && bot.Accept(view) {
+			decide = g.Accept
+		}
+		if err := decide(); err != nil {
+			return err
+		}
+	}
+	for player.Active() {
+		if player.Current().State() == game.AwaitingKeep {
+			if err := g.Keep(bot.Keep(NewView(g))...); err != nil {
+				return fmt.Errorf("%s: %w", bot.Name(), err)
+			}
+			if view := NewView(g); view.Turn >= view.Opening && bot.Bank(view) {
+				return g.Bank()
+			}
+			continue
+		}
+		if err := g.Roll(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Seat returns the index of the current player
+func Seat(g *game.Game) int {
+	for i, player := range g.Players() {
+		if player == g.Current() {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewView returns what the current player can see of the game
+func NewView(g *game.Game) View {
+	players := g.Players()
+	seat := Seat(g)
+	view := View{Score: players[seat].Score(), Target: g.TargetFor(players[seat]), Opening: g.OpeningFor(players[seat])}
+	for i := 1; i < len(players); i++ {
+		view.Opponents = append(view.Opponents, players[(seat+i)%len(players)].Score())
+	}
+	if last, ok := g.FinalRound(); ok {
+		view.FinalRound = true
+		view.Remaining = (last - seat + len(players) - 1) % len(players)
+	}
+	if turn := players[seat].Current(); turn != nil {
+		view.Roll = turn.Dice()
+		view.Turn = turn.Result()
+		view.Available = turn.Available()
+	}
+	return view
+}
@@ -0,0 +1,288 @@
+package strategy
+
+import (
+	"sync"
+
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	// step is the smallest difference between two scores
+	step = 50
+	// horizon is the turn score beyond which the solver always banks
+	horizon = 30_000
+)
+
+// Option is a choice of dice to keep from a roll
+type Option struct {
+	Dice  []int
+	Score uint32
+}
+
+// Options returns the ways of keeping scoring dice from the roll, the
+// highest scoring way for each number of dice kept
+func Options(roll game.Roll) []Option {
+	byCount := make([]*Option, len(roll)+1)
+	combinations(roll, func(kept []int, score uint32) {
+		if len(kept) > 0 && (byCount[len(kept)] == nil || score > byCount[len(kept)].Score) {
+			byCount[len(kept)] = &Option{Dice: append([]int(nil), kept...), Score: score}
+		}
+	})
+	ret := make([]Option, 0, len(byCount))
+	for _, option := range byCount {
+		if option != nil {
+			ret = append(ret, *option)
+		}
+	}
+	return ret
+}
+
+// Solver knows the expected final score of a turn from any point, when
+// played to maximise that score. It ignores the other players, and what
+// a bank leaves for the next player to accept.
+type Solver struct {
+	outcomes [][]outcome
+	ev       *table
+	mu       sync.Mutex
+	reach    map[uint32]*table
+}
+
+// Roll returns the expected final score of rolling the dice now
+func (s *Solver) Roll(score uint32, dice int) float64 {
+	return s.ev.roll(score, dice)
+}
+
+// Value returns the expected final score of the turn when the player can
+// bank or roll
+func (s *Solver) Value(score uint32, dice int) float64 {
+	return s.ev.value(score, dice)
+}
+
+// Keep returns the best dice to keep from the roll and the expected final
+// score after keeping them
+func (s *Solver) Keep(score uint32, roll game.Roll) (Option, float64) {
+	return s.ev.keep(score, roll)
+}
+
+// Reach returns the chance of a turn scoring at least need, when played
+// to maximise that chance and about to roll the dice
+func (s *Solver) Reach(score uint32, dice int, need uint32) float64 {
+	s.mu.Lock()
+	t, ok := s.reach[need]
+	if !ok {
+		t = s.solve(need, func(score uint32) float64 {
+			if score >= need {
+				return 1
+			}
+			return 0
+		})
+		s.reach[need] = t
+	}
+	s.mu.Unlock()
+	return t.roll(score, dice)
+}
+
+// Outcomes returns the chance of each final score of a turn about to roll
+// the dice, by score step, when played to maximise its expected score.
+// Scores beyond the last step are counted in it.
+func (s *Solver) Outcomes(score uint32, dice int) []float64 {
+	ret := make([]float64, horizon/step+1)
+	pending := make([][startDice + 1]float64, horizon/step+1)
+	if score >= horizon {
+		ret[horizon/step] = 1
+		return ret
+	}
+	pending[score/step][dice] = 1
+	for i := range pending {
+		score := uint32(i * step)
+		for dice, chance := range pending[i] {
+			if chance == 0 {
+				continue
+			}
+			for _, o := range s.outcomes[dice] {
+				best, kept, value := uint32(0), 0, -1.0
+				for k, gained := range o.scores {
+					if gained == 0 {
+						continue
+					}
+					if v := s.Value(score+gained, left(dice, k)); v > value {
+						best, kept, value = score+gained, k, v
+					}
+				}
+				switch next := left(dice, kept); {
+				case value < 0:
+					ret[0] += chance * o.chance
+				case best >= horizon:
+					ret[horizon/step] += chance * o.chance
+				case float64(best) >= s.Roll(best, next):
+					ret[best/step] += chance * o.chance
+				default:
+					pending[best/step][next] += chance * o.chance
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// table holds the value of rolling at each score step and number of
+// dice, for a turn whose final score is valued by utility. Turns bank once
+// they reach the limit.
+type table struct {
+	rolls   [][startDice + 1]float64
+	limit   uint32
+	utility func(score uint32) float64
+}
+
+func (t *table) roll(score uint32, dice int) float64 {
+	if score >= t.limit {
+		return t.utility(score)
+	}
+	return t.rolls[score/step][dice]
+}
+
+func (t *table) value(score uint32, dice int) float64 {
+	return max(t.utility(score), t.roll(score, dice))
+}
+
+func (t *table) keep(score uint32, roll game.Roll) (Option, float64) {
+	var (
+		best  Option
+		value = -1.0
+	)
+	for _, option := range Options(roll) {
+		if v := t.value(score+option.Score, left(len(roll), len(option.Dice))); v > value {
+			best, value = option, v
+		}
+	}
+	if value < 0 {
+		return best, t.utility(0)
+	}
+	return best, value
+}
+
+// solve works back from the limit to value every point of a turn
+func (s *Solver) solve(limit uint32, utility func(score uint32) float64) *table {
+	t := &table{rolls: make([][startDice + 1]float64, limit/step+1), limit: limit, utility: utility}
+	for i := int(limit / step); i >= 0; i-- {
+		score := uint32(i * step)
+		for dice := 1; dice <= startDice; dice++ {
+			var expected float64
+			for _, o := range s.outcomes[dice] {
+				best := utility(0)
+				for kept, gained := range o.scores {
+					if gained > 0 {
+						best = max(best, t.value(score+gained, left(dice, kept)))
+					}
+				}
+				expected += o.chance * best
+			}
+			t.rolls[i][dice] = expected
+		}
+	}
+	return t
+}
+
+func NewSolver() *Solver {
+	s := &Solver{outcomes: make([][]outcome, startDice+1), reach: make(map[uint32]*table)}
+	for dice := 1; dice <= startDice; dice++ {
+		s.outcomes[dice] = rolls(dice)
+	}
+	s.ev = s.solve(horizon, func(score uint32) float64 { return float64(score) })
+	return s
+}
+
+// outcome is a distinct roll, regardless of order, with its chance of
+// being rolled and the best score for each number of dice kept
+type outcome struct {
+	chance float64
+	scores []uint32
+}
+
+// rolls returns every distinct roll of the dice
+func rolls(dice int) []outcome {
+	var (
+		ret   []outcome
+		roll  = make(game.Roll, dice)
+		total = 1.0
+	)
+	for range dice {
+		total *= 6
+	}
+	var visit func(i int, from uint8)
+	visit = func(i int, from uint8) {
+		if i == dice {
+			o := outcome{chance: float64(orderings(roll)) / total, scores: make([]uint32, dice+1)}
+			for _, option := range Options(roll) {
+				o.scores[len(option.Dice)] = option.Score
+			}
+			ret = append(ret, o)
+			return
+		}
+		for d := from; d <= 6; d++ {
+			roll[i] = d
+			visit(i+1, d)
+		}
+	}
+	visit(0, 1)
+	return ret
+}
+
+// orderings returns the number of ways the dice of a sorted roll can be ordered
+func orderings(roll game.Roll) int {
+	n := factorial(len(roll))
+	for i := 0; i < len(roll); {
+		j := i
+		for j < len(roll) && roll[j] == roll[i] {
+			j++
+		}
+		n /= factorial(j - i)
+		i = j
+	}
+	return n
+}
+
+func factorial(n int) int {
+	ret := 1
+	for i := 2; i <= n; i++ {
+		ret *= i
+	}
+	return ret
+}
+
+// left returns the dice left to roll after keeping some, all six being
+// rolled again once every die has scored
+func left(dice, kept int) int {
+	if dice == kept {
+		return startDice
+	}
+	return dice - kept
+}
+
+var solver = sync.OnceValue(NewSolver)
+
+type optimal struct {
+	solver *Solver
+}
+
+func (o *optimal) Name() string {
+	return "optimal"
+}
+
+func (o *optimal) Accept(view View) bool {
+	return o.solver.Roll(view.Turn, view.Available) > o.solver.Roll(0, startDice)
+}
+
+func (o *optimal) Keep(view View) []int {
+	option, _ := o.solver.Keep(view.Turn, view.Roll)
+	return option.Dice
+}
+
+func (o *optimal) Bank(view View) bool {
+	return float64(view.Turn) >= o.solver.Roll(view.Turn, view.Available)
+}
+
+// Optimal plays each turn to maximise its expected score
+func Optimal() Strategy {
+	return &optimal{solver: solver()}
+}
@@ -0,0 +1,112 @@
+// Package strategy provides computer players and the engine that plays
+// whole games with them
+package strategy
+
+import (
+	"github.com/ryannatesmith/farkle/game"
+)
+
+const (
+	startDice = 6
+)
+
+// View is everything a strategy can see when it must decide
+type View struct {
+	// Roll is the most recent roll, when choosing dice to keep
+	Roll game.Roll
+	// Turn is the score of the turn so far, or of the offer when accepting
+	Turn uint32
+	// Available is the number of dice left to roll
+	Available int
+	// Score is the player's banked score
+	Score uint32
+	// Opponents are the other players' banked scores, in the order they play
+	Opponents []uint32
+	// Target is the score the player must reach, after any handicap
+	Target uint32
+	// Opening is the score the turn must reach before it can be banked,
+	// until the player is on the board
+	Opening uint32
+	// FinalRound is set once a player has reached the target, and then
+	// Remaining is the number of opponents still to play after this turn
+	FinalRound bool
+	Remaining  int
+}
+
+// Strategy decides how a computer player plays its turns
+type Strategy interface {
+	Name() string
+	// Accept decides whether to take the dice left by the previous player
+	Accept(view View) bool
+	// Keep chooses which dice of the roll to keep
+	Keep(view View) []int
+	// Bank decides whether to stop rolling and bank the turn
+	Bank(view View) bool
+}
+
+type threshold struct {
+	name   string
+	bank   uint32
+	accept int
+	dice   int
+}
+
+func (t *threshold) Name() string {
+	return t.name
+}
+
+func (t *threshold) Accept(view View) bool {
+	return view.Available >= t.accept
+}
+
+func (t *threshold) Keep(view View) []int {
+	dice, _ := Best(view.Roll)
+	return dice
+}
+
+func (t *threshold) Bank(view View) bool {
+	return view.Turn >= t.bank || view.Available < t.dice
+}
+
+// Threshold keeps every scoring die and banks once the turn reaches
+// score, or when fewer than dice remain to roll. It accepts offers of
+// at least three dice.
+func Threshold(name string, score uint32, dice int) Strategy {
+	return &threshold{name: name, bank: score, accept: 3, dice: dice}
+}
+
+// Best returns the dice that score the most from the roll, and that score
+func Best(roll game.Roll) ([]int, uint32) {
+	var (
+		best uint32
+		dice []int
+	)
+	combinations(roll, func(kept []int, score uint32) {
+		if score > best || (score == best && len(kept) < len(dice)) {
+			best = score
+			dice = append([]int(nil), kept...)
+		}
+	})
+	return dice, best
+}
+
+// combinations calls yield with every way of keeping scoring dice from
+// the roll, including keeping none
+func combinations(roll game.Roll, yield func(kept []int, score uint32)) {
+	scorings := roll.Scorings()
+	var visit func(from int, used uint8, score uint32, kept []int)
+	visit = func(from int, used uint8, score uint32, kept []int) {
+		yield(kept, score)
+		for i := from; i < len(scorings); i++ {
+			var mask uint8
+			for _, j := range scorings[i].Set {
+				mask |= 1 << j
+			}
+			if used&mask != 0 {
+				continue
+			}
+			visit(i+1, used|mask, score+scorings[i].Score, append(kept, scorings[i].Set...))
+		}
+	}
+	visit(0, 0, 0, nil)
+}
@@ -0,0 +1,201 @@
+package strategy_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+func TestBest(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		roll  game.Roll
+		dice  []int
+		score uint32
+	}
+	for _, c := range []testCase{
+		{
+			name:  "three ones and a five",
+			roll:  game.Roll{1, 1, 1, 5, 4, 2},
+			dice:  []int{0, 1, 2, 3},
+			score: 350,
+		},
+		{
+			name:  "four of a kind and a one",
+			roll:  game.Roll{3, 3, 3, 3, 1},
+			dice:  []int{0, 1, 2, 3, 4},
+			score: 1100,
+		},
+		{
+			name:  "straight",
+			roll:  game.Roll{1, 2, 3, 4, 5, 6},
+			dice:  []int{0, 1, 2, 3, 4, 5},
+			score: 1500,
+		},
+		{
+			name: "farkle",
+			roll: game.Roll{2, 3, 4, 6},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			dice, score := strategy.Best(c.roll)
+			if diff := cmp.Diff(c.dice, dice); diff != "" {
+				t.Error("+want -got", diff)
+			}
+			if score != c.score {
+				t.Errorf("score: +want -got\n\t+%d\n\t-%d", c.score, score)
+			}
+		})
+	}
+}
+
+func TestPlay(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(game.NewSeededRandom(1)), game.WithTarget(3_000))
+	g.Join("cautious")
+	g.Join("bold")
+	err := strategy.Play(g, strategy.Threshold("cautious", 300, 3), strategy.Threshold("bold", 1_000, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Over() || g.Winner().Score() < 3_000 {
+		t.Errorf("game should be over with a winner over the target, got %d", g.Winner().Score())
+	}
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+	got := strategy.Options(game.Roll{1, 1, 1, 5, 4, 2})
+	want := []strategy.Option{
+		{Dice: []int{0}, Score: 100},
+		{Dice: []int{0, 1}, Score: 200},
+		{Dice: []int{0, 1, 2}, Score: 300},
+		{Dice: []int{0, 1, 2, 3}, Score: 350},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("+want -got", diff)
+	}
+}
+
+func TestSolver(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		score uint32
+		dice  int
+		bank  bool
+	}
+	for _, c := range []testCase{
+		{name: "roll six dice at zero", score: 0, dice: 6},
+		{name: "roll six dice at two thousand", score: 2_000, dice: 6},
+		{name: "roll three dice at three hundred", score: 300, dice: 3},
+		{name: "bank two dice at three hundred", score: 300, dice: 2, bank: true},
+		{name: "bank one die at a thousand", score: 1_000, dice: 1, bank: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			bot := strategy.Optimal()
+			if got := bot.Bank(strategy.View{Turn: c.score, Available: c.dice}); got != c.bank {
+				t.Errorf("bank: +want -got\n\t+%v\n\t-%v", c.bank, got)
+			}
+		})
+	}
+	solver := strategy.NewSolver()
+	option, value := solver.Keep(0, game.Roll{1, 2, 3, 4, 6, 6})
+	if diff := cmp.Diff([]int{0}, option.Dice); diff != "" || value <= 100 {
+		t.Errorf("unexpected keep %v worth %v", option, value)
+	}
+}
+
+func TestSolver_Outcomes(t *testing.T) {
+	t.Parallel()
+	solver := strategy.NewSolver()
+	var total, mean float64
+	for i, chance := range solver.Outcomes(0, 6) {
+		total += chance
+		mean += chance * float64(i*50)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("chances sum to %v", total)
+	}
+	if want := solver.Roll(0, 6); math.Abs(mean-want) > 1 {
+		t.Errorf("mean: +want -got\n\t+%v\n\t-%v", want, mean)
+	}
+}
+
+func TestEndgame(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		view strategy.View
+		bank bool
+	}
+	for _, c := range []testCase{
+		{
+			name: "roll on when behind in the final round",
+			view: strategy.View{Turn: 500, Available: 3, Score: 5_000, Opponents: []uint32{10_000}, Target: 10_000, FinalRound: true},
+		},
+		{
+			name: "bank once ahead in the final round",
+			view: strategy.View{Turn: 550, Available: 2, Score: 9_500, Opponents: []uint32{10_000}, Target: 10_000, FinalRound: true},
+			bank: true,
+		},
+		{
+			name: "bank early in the game",
+			view: strategy.View{Turn: 1_000, Available: 1, Score: 0, Opponents: []uint32{0}, Target: 10_000},
+			bank: true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			if got := strategy.Endgame().Bank(c.view); got != c.bank {
+				t.Errorf("bank: +want -got\n\t+%v\n\t-%v", c.bank, got)
+			}
+		})
+	}
+}
+
+func TestWinChances(t *testing.T) {
+	t.Parallel()
+	g := game.NewGame(game.WithRandom(game.NewSeededRandom(3)), game.WithTarget(2_000))
+	g.Join("one")
+	g.Join("two")
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	chances := strategy.WinChances(g)
+	if math.Abs(chances[0]+chances[1]-1) > 1e-9 || chances[0] < 0.4 {
+		t.Errorf("unexpected chances at the start %v", chances)
+	}
+	if err := strategy.Play(g, strategy.Endgame(), strategy.Optimal()); err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{1, 0}
+	if g.Winner() != g.Players()[0] {
+		want = []float64{0, 1}
+	}
+	if diff := cmp.Diff(want, strategy.WinChances(g)); diff != "" {
+		t.Errorf("chances once over: +want -got\n%s", diff)
+	}
+}
+
+func TestWinChances_Handicap(t *testing.T) {
+	t.Parallel()
+	if got := strategy.WinChances(game.NewGame()); len(got) != 0 {
+		t.Errorf("chances with no players %v", got)
+	}
+	g := game.NewGame(game.WithRandom(game.NewSeededRandom(3)), game.WithTarget(2_000))
+	g.Join("one")
+	g.Join("two", game.WithHandicap(game.Handicap{Target: 1_500}))
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if chances := strategy.WinChances(g); chances[1] < 0.7 {
+		t.Errorf("handicapped player's chances %v", chances)
+	}
+}
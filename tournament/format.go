@@ -0,0 +1,133 @@
+package tournament
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// roundRobin pairs every entrant with every other using the circle method
+func (t *Tournament) roundRobin() error {
+	ids := t.ids()
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+	for round := range len(ids) - 1 {
+		for i := range len(ids) / 2 {
+			home, away := ids[i], ids[len(ids)-1-i]
+			if round%2 == 1 {
+				home, away = away, home
+			}
+			if home == "" || away == "" {
+				continue
+			}
+			if _, err := t.play(round+1, home, away); err != nil {
+				return err
+			}
+		}
+		ids = append(ids[:1], append(ids[len(ids)-1:], ids[1:len(ids)-1]...)...)
+	}
+	return nil
+}
+
+// swiss pairs entrants with similar points who have not met before. The
+// lowest placed entrant without a bye sits out when the field is odd, or
+// the lowest placed of all once every entrant has had one.
+func (t *Tournament) swiss() error {
+	rounds := t.rounds
+	if rounds == 0 {
+		rounds = bits.Len(uint(len(t.entrants) - 1))
+	}
+	met := make(map[[2]string]bool)
+	byes := make(map[string]bool)
+	for round := 1; round <= rounds; round++ {
+		ids := make([]string, 0, len(t.entrants))
+		for _, standing := range t.standings() {
+			ids = append(ids, standing.ID)
+		}
+		if len(ids)%2 == 1 {
+			bye := len(ids) - 1
+			for i := len(ids) - 1; i >= 0; i-- {
+				if !byes[ids[i]] {
+					bye = i
+					break
+				}
+			}
+			byes[ids[bye]] = true
+			if _, err := t.play(round, ids[bye], ""); err != nil {
+				return err
+			}
+			ids = slices.Delete(ids, bye, bye+1)
+		}
+		for len(ids) > 0 {
+			home, opponent := ids[0], 1
+			for i := 1; i < len(ids); i++ {
+				if !met[[2]string{home, ids[i]}] {
+					opponent = i
+					break
+				}
+			}
+			away := ids[opponent]
+			met[[2]string{home, away}], met[[2]string{away, home}] = true, true
+			if _, err := t.play(round, home, away); err != nil {
+				return err
+			}
+			ids = slices.Delete(ids, opponent, opponent+1)[1:]
+		}
+	}
+	return nil
+}
+
+// singleElimination plays a seeded knockout bracket, the top seeds
+// receiving byes when the field is not a power of two
+func (t *Tournament) singleElimination() error {
+	size := 1 << bits.Len(uint(len(t.entrants)-1))
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	ids := make([]string, len(order))
+	for i, seed := range order {
+		if seed <= len(t.entrants) {
+			ids[i] = t.entrants[seed-1].ID
+		}
+	}
+	for round := 1; len(ids) > 1; round++ {
+		winners := make([]string, 0, len(ids)/2)
+		for i := 0; i < len(ids); i += 2 {
+			home, away := ids[i], ids[i+1]
+			if home == "" {
+				home, away = away, home
+			}
+			match, err := t.play(round, home, away)
+			if err != nil {
+				return err
+			}
+			winner := match.Winner()
+			if winner == "" {
+				winner = t.higherSeed(home, away)
+			}
+			winners = append(winners, winner)
+		}
+		ids = winners
+	}
+	return nil
+}
+
+func (t *Tournament) higherSeed(a, b string) string {
+	if t.seeds[a] < t.seeds[b] {
+		return a
+	}
+	return b
+}
+
+func (t *Tournament) ids() []string {
+	ids := make([]string, len(t.entrants))
+	for i, entrant := range t.entrants {
+		ids[i] = entrant.ID
+	}
+	return ids
+}
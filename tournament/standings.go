@@ -0,0 +1,107 @@
+package tournament
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// Standing is an entrant's record in the tournament. Entrants are ranked
+// by the knockout round reached, then points, then the points of the
+// opponents they met (Buchholz), then the margin of points scored over
+// conceded, and finally by seed.
+type Standing struct {
+	ID       string
+	Points   float64
+	Wins     int
+	Draws    int
+	Losses   int
+	Buchholz float64
+	Margin   int64
+	// Reached is the last knockout round won, and is zero in other formats
+	Reached int
+}
+
+func (t *Tournament) standings() []Standing {
+	byID := make(map[string]*Standing, len(t.entrants))
+	for _, entrant := range t.entrants {
+		byID[entrant.ID] = &Standing{ID: entrant.ID}
+	}
+	for _, match := range t.matches {
+		home := byID[match.Home]
+		if match.Away == "" {
+			home.Points++
+			home.Wins++
+			continue
+		}
+		away := byID[match.Away]
+		home.Margin += int64(match.HomeScore) - int64(match.AwayScore)
+		away.Margin += int64(match.AwayScore) - int64(match.HomeScore)
+		switch match.Winner() {
+		case match.Home:
+			home.Points++
+			home.Wins++
+			away.Losses++
+		case match.Away:
+			away.Points++
+			away.Wins++
+			home.Losses++
+		default:
+			home.Points += 0.5
+			away.Points += 0.5
+			home.Draws++
+			away.Draws++
+		}
+	}
+	for _, match := range t.matches {
+		if match.Away == "" {
+			continue
+		}
+		byID[match.Home].Buchholz += byID[match.Away].Points
+		byID[match.Away].Buchholz += byID[match.Home].Points
+	}
+	if t.format == SingleElimination {
+		for _, match := range t.matches {
+			winner := match.Winner()
+			if winner == "" {
+				winner = t.higherSeed(match.Home, match.Away)
+			}
+			byID[winner].Reached = max(byID[winner].Reached, match.Round)
+		}
+	}
+	ret := make([]Standing, 0, len(byID))
+	for _, entrant := range t.entrants {
+		ret = append(ret, *byID[entrant.ID])
+	}
+	sortStandings(ret, t.seeds)
+	return ret
+}
+
+// WriteStandings writes the standings as an aligned table
+func WriteStandings(w io.Writer, standings []Standing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "#\tentrant\tpoints\tW\tD\tL\tbuchholz\tmargin\t")
+	for i, s := range standings {
+		fmt.Fprintf(tw, "%d\t%s\t%.1f\t%d\t%d\t%d\t%.1f\t%+d\t\n", i+1, s.ID, s.Points, s.Wins, s.Draws, s.Losses, s.Buchholz, s.Margin)
+	}
+	return tw.Flush()
+}
+
+func sortStandings(standings []Standing, seeds map[string]int) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		switch {
+		case a.Reached != b.Reached:
+			return a.Reached > b.Reached
+		case a.Points != b.Points:
+			return a.Points > b.Points
+		case a.Buchholz != b.Buchholz:
+			return a.Buchholz > b.Buchholz
+		case a.Margin != b.Margin:
+			return a.Margin > b.Margin
+		default:
+			return seeds[a.ID] < seeds[b.ID]
+		}
+	})
+}
@@ -0,0 +1,199 @@
+// Package tournament schedules and plays many games between a field of
+// computer players
+package tournament
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ryannatesmith/farkle/game"
+	"github.com/ryannatesmith/farkle/strategy"
+)
+
+var ErrTooFewEntrants = errors.New("a tournament needs at least two entrants")
+
+type Format int
+
+const (
+	RoundRobin Format = iota
+	Swiss
+	SingleElimination
+)
+
+type Opt func(*Tournament)
+
+func WithFormat(format Format) Opt {
+	return func(t *Tournament) {
+		t.format = format
+	}
+}
+
+// WithSeed sets the seed the dice of every game are derived from, so the
+// same tournament can be replayed exactly
+func WithSeed(seed uint64) Opt {
+	return func(t *Tournament) {
+		t.seed = seed
+	}
+}
+
+// WithGamesPerMatch sets how many games each pairing plays. Seats
+// alternate between games to cancel out the first player's advantage.
+func WithGamesPerMatch(games int) Opt {
+	return func(t *Tournament) {
+		t.games = games
+	}
+}
+
+// WithRounds sets the number of rounds in a Swiss tournament
+func WithRounds(rounds int) Opt {
+	return func(t *Tournament) {
+		t.rounds = rounds
+	}
+}
+
+// WithTarget sets the target score of every game
+func WithTarget(score uint32) Opt {
+	return func(t *Tournament) {
+		t.target = score
+	}
+}
+
+// Entrant is a computer player in the tournament. Entrants are seeded in
+// the order they are given.
+type Entrant struct {
+	ID       string
+	Strategy strategy.Strategy
+}
+
+// Match is a pairing of two entrants over one or more games. Away is
+// empty for a bye.
+type Match struct {
+	Round     int
+	Home      string
+	Away      string
+	HomeWins  int
+	AwayWins  int
+	HomeScore uint64
+	AwayScore uint64
+}
+
+// Winner returns the entrant with the most wins, then the most points,
+// or an empty string for a draw
+func (m Match) Winner() string {
+	switch {
+	case m.Away == "":
+		return m.Home
+	case m.HomeWins != m.AwayWins:
+		if m.HomeWins > m.AwayWins {
+			return m.Home
+		}
+		return m.Away
+	case m.HomeScore != m.AwayScore:
+		if m.HomeScore > m.AwayScore {
+			return m.Home
+		}
+		return m.Away
+	default:
+		return ""
+	}
+}
+
+type Tournament struct {
+	entrants []Entrant
+	seeds    map[string]int
+	format   Format
+	seed     uint64
+	games    int
+	rounds   int
+	target   uint32
+	played   uint64
+	matches  []Match
+}
+
+// Run plays every round of the tournament and returns the final standings
+func (t *Tournament) Run() ([]Standing, error) {
+	t.matches, t.played = nil, 0
+	var err error
+	switch t.format {
+	case RoundRobin:
+		err = t.roundRobin()
+	case Swiss:
+		err = t.swiss()
+	case SingleElimination:
+		err = t.singleElimination()
+	default:
+		err = fmt.Errorf("unknown format %d", t.format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.standings(), nil
+}
+
+// Matches returns every match played, in order
+func (t *Tournament) Matches() []Match {
+	return t.matches
+}
+
+func (t *Tournament) play(round int, home, away string) (Match, error) {
+	match := Match{Round: round, Home: home, Away: away}
+	if away == "" {
+		t.matches = append(t.matches, match)
+		return match, nil
+	}
+	for i := range t.games {
+		seats := []string{home, away}
+		if i%2 == 1 {
+			seats = []string{away, home}
+		}
+		g := game.NewGame(
+			game.WithRandom(game.NewSeededRandom(t.seed+t.played)),
+			game.WithTarget(t.target),
+			game.WithoutUndo(),
+		)
+		t.played++
+		bots := make([]strategy.Strategy, len(seats))
+		for j, id := range seats {
+			g.Join(id, game.WithID(id))
+			bots[j] = t.entrants[t.seeds[id]].Strategy
+		}
+		if err := strategy.Play(g, bots...); err != nil {
+			return match, fmt.Errorf("round %d, %s v %s: %w", round, home, away, err)
+		}
+		var scores [2]uint32
+		for _, player := range g.Players() {
+			if player.ID() == home {
+				scores[0] = player.Score()
+			} else {
+				scores[1] = player.Score()
+			}
+		}
+		match.HomeScore += uint64(scores[0])
+		match.AwayScore += uint64(scores[1])
+		switch {
+		case scores[0] > scores[1]:
+			match.HomeWins++
+		case scores[1] > scores[0]:
+			match.AwayWins++
+		}
+	}
+	t.matches = append(t.matches, match)
+	return match, nil
+}
+
+func New(entrants []Entrant, opts ...Opt) (*Tournament, error) {
+	if len(entrants) < 2 {
+		return nil, ErrTooFewEntrants
+	}
+	t := &Tournament{entrants: entrants, seeds: make(map[string]int), games: 2}
+	for i, entrant := range entrants {
+		if _, ok := t.seeds[entrant.ID]; ok || entrant.ID == "" {
+			return nil, fmt.Errorf("entrant IDs must be unique and not empty: %q", entrant.ID)
+		}
+		t.seeds[entrant.ID] = i
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t, nil
+}
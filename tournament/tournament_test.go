@@ -0,0 +1,192 @@
+package tournament_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryannatesmith/farkle/strategy"
+	"github.com/ryannatesmith/farkle/tournament"
+)
+
+func TestTournament_Run(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name     string
+		entrants int
+		format   tournament.Format
+		opts     []tournament.Opt
+		matches  int
+		points   float64
+		// meetings is the number of times every pair should meet, when
+		// they all should
+		meetings int
+	}
+	for _, c := range []testCase{
+		{
+			name:     "round robin",
+			entrants: 4,
+			format:   tournament.RoundRobin,
+			matches:  6,
+			points:   6,
+			meetings: 1,
+		},
+		{
+			name:     "round robin with bye",
+			entrants: 3,
+			format:   tournament.RoundRobin,
+			matches:  3,
+			points:   3,
+			meetings: 1,
+		},
+		{
+			name:     "swiss",
+			entrants: 5,
+			format:   tournament.Swiss,
+			matches:  9,
+			points:   9,
+		},
+		{
+			name:     "swiss with more rounds than byes",
+			entrants: 3,
+			format:   tournament.Swiss,
+			opts:     []tournament.Opt{tournament.WithRounds(4)},
+			matches:  8,
+			points:   8,
+		},
+		{
+			name:     "single elimination",
+			entrants: 6,
+			format:   tournament.SingleElimination,
+			matches:  7,
+			points:   7,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			run := func() ([]tournament.Standing, []tournament.Match) {
+				entrants := make([]tournament.Entrant, c.entrants)
+				for i := range entrants {
+					id := string(rune('a' + i))
+					entrants[i] = tournament.Entrant{ID: id, Strategy: strategy.Threshold(id, uint32(300+100*i), 2)}
+				}
+				opts := append([]tournament.Opt{tournament.WithFormat(c.format), tournament.WithSeed(7), tournament.WithTarget(2_000)}, c.opts...)
+				tour, err := tournament.New(entrants, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				standings, err := tour.Run()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return standings, tour.Matches()
+			}
+			standings, matches := run()
+			if len(matches) != c.matches {
+				t.Errorf("matches: +want -got\n\t+%d\n\t-%d", c.matches, len(matches))
+			}
+			var points float64
+			for _, s := range standings {
+				points += s.Points
+			}
+			if points != c.points {
+				t.Errorf("points: +want -got\n\t+%v\n\t-%v", c.points, points)
+			}
+			if c.meetings > 0 {
+				checkMeetings(t, c.entrants, matches, c.meetings)
+			}
+			if c.format == tournament.SingleElimination {
+				checkBracket(t, matches)
+			}
+			again, _ := run()
+			if diff := cmp.Diff(standings, again); diff != "" {
+				t.Error("tournament not reproducible", diff)
+			}
+			var buf bytes.Buffer
+			if err := tournament.WriteStandings(&buf, standings); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// checkMeetings checks every pair of entrants met the given number of
+// times, byes aside
+func checkMeetings(t *testing.T, entrants int, matches []tournament.Match, want int) {
+	t.Helper()
+	met := make(map[[2]string]int)
+	for _, m := range matches {
+		if m.Away != "" {
+			met[[2]string{min(m.Home, m.Away), max(m.Home, m.Away)}]++
+		}
+	}
+	for i := range entrants {
+		for j := i + 1; j < entrants; j++ {
+			pair := [2]string{string(rune('a' + i)), string(rune('a' + j))}
+			if met[pair] != want {
+				t.Errorf("%s and %s met: +want -got\n\t+%d\n\t-%d", pair[0], pair[1], want, met[pair])
+			}
+		}
+	}
+}
+
+// checkBracket checks the entrants of each knockout round are the winners
+// of the one before, a drawn match going to the higher seed, and that the
+// champion played every round
+func checkBracket(t *testing.T, matches []tournament.Match) {
+	t.Helper()
+	rounds := make(map[int][]tournament.Match)
+	last := 0
+	for _, m := range matches {
+		rounds[m.Round] = append(rounds[m.Round], m)
+		last = max(last, m.Round)
+	}
+	if len(rounds[last]) != 1 {
+		t.Fatalf("final round has %d matches", len(rounds[last]))
+	}
+	champion := rounds[last][0].Winner()
+	if champion == "" {
+		champion = min(rounds[last][0].Home, rounds[last][0].Away)
+	}
+	for round := 1; round <= last; round++ {
+		var entrants []string
+		for _, m := range rounds[round] {
+			entrants = append(entrants, m.Home)
+			if m.Away != "" {
+				entrants = append(entrants, m.Away)
+			}
+		}
+		if !slices.Contains(entrants, champion) {
+			t.Errorf("champion %s missing from round %d", champion, round)
+		}
+		if round == 1 {
+			continue
+		}
+		var winners []string
+		for _, m := range rounds[round-1] {
+			winner := m.Winner()
+			if winner == "" {
+				// entrants are named in seed order
+				winner = min(m.Home, m.Away)
+			}
+			winners = append(winners, winner)
+		}
+		slices.Sort(entrants)
+		slices.Sort(winners)
+		if diff := cmp.Diff(winners, entrants); diff != "" {
+			t.Errorf("round %d: +want -got\n%s", round, diff)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	bot := strategy.Threshold("bot", 300, 2)
+	if _, err := tournament.New([]tournament.Entrant{{ID: "a", Strategy: bot}}); err == nil {
+		t.Error("should have got error for one entrant")
+	}
+	if _, err := tournament.New([]tournament.Entrant{{ID: "a", Strategy: bot}, {ID: "a", Strategy: bot}}); err == nil {
+		t.Error("should have got error for duplicate entrants")
+	}
+}